@@ -7,6 +7,7 @@ import (
 	_ "embed"
 
 	"github.com/Darkness4/blog/meilisearch"
+	"github.com/Darkness4/blog/utils/i18n"
 	"github.com/Masterminds/sprig/v3"
 	"github.com/rs/zerolog/log"
 )
@@ -43,8 +44,13 @@ func Handler(meili *meilisearch.Client) http.HandlerFunc {
 		if q == "" {
 			return
 		}
+		lang := r.URL.Query().Get("lang")
+		if lang != "" && !i18n.Supported(lang) {
+			http.Error(w, "unsupported language", http.StatusBadRequest)
+			return
+		}
 
-		res, err := meili.Search(ctx, q)
+		res, err := meili.Search(ctx, q, lang)
 		if err != nil {
 			log.Err(err).Msg("search failure")
 			http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -0,0 +1,27 @@
+package search_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Darkness4/blog/api/search"
+	"github.com/Darkness4/blog/meilisearch"
+)
+
+func TestHandlerUnsupportedLanguage(t *testing.T) {
+	// The request is rejected before reaching the search engine.
+	h := search.Handler(&meilisearch.Client{})
+	for _, lang := range []string{"xx", `en" OR lang != "en`} {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/search", nil)
+		q := req.URL.Query()
+		q.Set("q", "go")
+		q.Set("lang", lang)
+		req.URL.RawQuery = q.Encode()
+		h.ServeHTTP(rec, req)
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%q: expected status %d, got %d", lang, http.StatusBadRequest, rec.Code)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package d2
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+	"regexp"
+	"strconv"
+
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/util"
+)
+
+// Asset is a diagram rendered as an external SVG file.
+type Asset struct {
+	// Name is the file name of the asset. It contains the hash of the content,
+	// so the asset can be cached forever.
+	Name string
+	Data []byte
+}
+
+// Assets returns the external diagrams of a rendered document. They must be
+// written next to the page.
+func Assets(doc ast.Node) []Asset {
+	var assets []Asset
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if b, ok := n.(*Block); ok && entering {
+			assets = append(assets, b.assets...)
+		}
+		return ast.WalkContinue, nil
+	})
+	return assets
+}
+
+func newAsset(svg []byte) Asset {
+	sum := sha256.Sum256(svg)
+	return Asset{
+		Name: "diagram." + hex.EncodeToString(sum[:8]) + ".svg",
+		Data: svg,
+	}
+}
+
+var (
+	widthAttr  = regexp.MustCompile(`\swidth="([0-9.]+)"`)
+	heightAttr = regexp.MustCompile(`\sheight="([0-9.]+)"`)
+)
+
+// svgSize returns the size of the root element of a SVG, rounded up. It is 0
+// if unknown.
+func svgSize(svg []byte) (width, height int) {
+	start := bytes.Index(svg, []byte("<svg"))
+	if start < 0 {
+		return 0, 0
+	}
+	end := bytes.IndexByte(svg[start:], '>')
+	if end < 0 {
+		return 0, 0
+	}
+	tag := svg[start : start+end]
+	parse := func(re *regexp.Regexp) int {
+		m := re.FindSubmatch(tag)
+		if m == nil {
+			return 0
+		}
+		f, _ := strconv.ParseFloat(string(m[1]), 64)
+		return int(math.Ceil(f))
+	}
+	return parse(widthAttr), parse(heightAttr)
+}
+
+// writePicture links the renderings of a board as external assets, and
+// returns their URLs. The rendering shown follows prefers-color-scheme.
+func (r *HTMLRenderer) writePicture(w util.BufWriter, n *Block, vs []variant, outs [][]byte, alt string) []string {
+	urls := make([]string, len(outs))
+	for i, out := range outs {
+		asset := newAsset(out)
+		n.assets = append(n.assets, asset)
+		urls[i] = r.AssetURL(asset.Name)
+	}
+	if alt == "" {
+		alt = "Diagram"
+	}
+	width, height := svgSize(outs[0])
+
+	_, _ = w.WriteString("<picture>")
+	for i, v := range vs {
+		if v.class == "d2-dark" {
+			_, _ = w.WriteString(`<source srcset="` + urls[i] + `" media="(prefers-color-scheme: dark)">`)
+		}
+	}
+	_, _ = w.WriteString(`<img src="` + urls[0] + `" alt="`)
+	_, _ = w.Write(util.EscapeHTML([]byte(alt)))
+	_ = w.WriteByte('"')
+	if width > 0 && height > 0 {
+		_, _ = w.WriteString(` width="` + strconv.Itoa(width) + `" height="` + strconv.Itoa(height) + `"`)
+	}
+	_, _ = w.WriteString(` loading="lazy"></picture>`)
+	return urls
+}
@@ -8,6 +8,10 @@ import (
 type Block struct {
 	ast.BaseBlock
 	info []byte
+	// Options are the default options of the page.
+	Options Options
+	errs    []*Error
+	assets  []Asset
 }
 
 func (n *Block) IsBlank(source []byte) bool {
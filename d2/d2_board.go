@@ -0,0 +1,81 @@
+package d2
+
+import (
+	"fmt"
+
+	"oss.terrastruct.com/d2/d2renderers/d2animate"
+	"oss.terrastruct.com/d2/d2renderers/d2svg"
+	"oss.terrastruct.com/d2/d2target"
+)
+
+// boards returns the boards of a diagram: the root first, then the layers,
+// the scenarios and the steps.
+func boards(diagram *d2target.Diagram) []*d2target.Diagram {
+	var out []*d2target.Diagram
+	if !diagram.IsFolderOnly {
+		out = append(out, diagram)
+	}
+	for _, children := range [][]*d2target.Diagram{diagram.Layers, diagram.Scenarios, diagram.Steps} {
+		for _, child := range children {
+			out = append(out, boards(child)...)
+		}
+	}
+	return out
+}
+
+// renderBoards renders every board of a diagram and returns their names.
+//
+// If animate is set and positive, the boards are rendered as one animated SVG
+// showing each board for animate milliseconds.
+func renderBoards(
+	diagram *d2target.Diagram,
+	opts d2svg.RenderOpts,
+	animate *int64,
+) (names []string, svgs [][]byte, err error) {
+	all := boards(diagram)
+	animated := animate != nil && *animate > 0 && len(all) > 1
+	if animated {
+		// The boards share the IDs of the root, as in the d2 CLI.
+		opts.MasterID, err = diagram.HashID(opts.Salt)
+		if err != nil {
+			return nil, nil, err
+		}
+		if opts.Pad == nil {
+			pad := int64(d2svg.DEFAULT_PADDING)
+			opts.Pad = &pad
+		}
+	}
+	for _, board := range all {
+		out, err := d2svg.Render(board, &opts)
+		if err != nil {
+			return nil, nil, fmt.Errorf("board %q: %w", board.Name, err)
+		}
+		name := board.Name
+		if board == diagram && name == "" {
+			name = "Overview"
+		}
+		names = append(names, name)
+		svgs = append(svgs, out)
+	}
+	if !animated {
+		return names, svgs, nil
+	}
+	out, err := d2animate.Wrap(diagram, svgs, opts, int(*animate))
+	if err != nil {
+		return nil, nil, err
+	}
+	return []string{diagram.Name}, [][]byte{out}, nil
+}
+
+// boardLabel is the label of the board i of a stepper.
+func boardLabel(i, n int, name string) string {
+	return fmt.Sprintf("%d / %d · %s", i+1, n, name)
+}
+
+// boardAlt is the alternative text of a board of a stepper.
+func boardAlt(title, name string) string {
+	if title == "" {
+		return name
+	}
+	return title + " (" + name + ")"
+}
@@ -0,0 +1,102 @@
+package d2
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/yuin/goldmark/ast"
+	"oss.terrastruct.com/d2/d2parser"
+)
+
+// frameContext is the number of lines shown around the line of an error.
+const frameContext = 2
+
+// Error is an error in a diagram, located in the markdown file.
+type Error struct {
+	Path   string
+	Line   int
+	Column int
+	Err    error
+	// Frame is the source of the diagram around the error.
+	Frame string
+}
+
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("%s:%d:%d: d2: %v", e.Path, e.Line, e.Column, e.Err)
+	if e.Frame != "" {
+		msg += "\n" + e.Frame
+	}
+	return msg
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Errors returns the errors of the diagrams of a rendered document. Their Path
+// is left empty.
+func Errors(doc ast.Node) []*Error {
+	var errs []*Error
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if b, ok := n.(*Block); ok && entering {
+			errs = append(errs, b.errs...)
+		}
+		return ast.WalkContinue, nil
+	})
+	return errs
+}
+
+// newErrors maps an error of the diagram of n to the lines of the markdown
+// source. The errors without a position are located on the opening fence.
+func newErrors(err error, n *Block, src []byte) []*Error {
+	var perr *d2parser.ParseError
+	if !errors.As(err, &perr) || len(perr.Errors) == 0 {
+		return []*Error{n.newError(-1, 0, err, src)}
+	}
+	errs := make([]*Error, 0, len(perr.Errors))
+	for _, e := range perr.Errors {
+		msg := strings.TrimPrefix(e.Message, e.Range.String()+": ")
+		errs = append(errs, n.newError(e.Range.Start.Line, e.Range.Start.Column, errors.New(msg), src))
+	}
+	return errs
+}
+
+// newError returns the error at the line and column of the diagram, both
+// starting at 0. Line -1 is the opening fence.
+func (n *Block) newError(line, column int, err error, src []byte) *Error {
+	lines := n.Lines()
+	first := lines.At(0)
+	e := &Error{
+		// The opening fence is the line before the first line of the diagram.
+		Line:   bytes.Count(src[:first.Start], []byte("\n")),
+		Column: 1,
+		Err:    err,
+	}
+	if line < 0 || line >= lines.Len() {
+		return e
+	}
+	e.Line += line + 1
+	segment := lines.At(line)
+	// The segment of an indented block starts after the indentation.
+	lineStart := bytes.LastIndexByte(src[:segment.Start], '\n') + 1
+	e.Column = segment.Start - lineStart + column + 1
+
+	var frame strings.Builder
+	width := len(fmt.Sprint(e.Line + frameContext))
+	for i := max(0, line-frameContext); i <= min(lines.Len()-1, line+frameContext); i++ {
+		segment := lines.At(i)
+		marker := " "
+		if i == line {
+			marker = ">"
+		}
+		fmt.Fprintf(&frame, "%s %*d | %s\n", marker, width, e.Line-line+i,
+			strings.TrimRight(string(segment.Value(src)), "\r\n"))
+		if i == line {
+			fmt.Fprintf(&frame, "  %*s | %s^\n", width, "", strings.Repeat(" ", column))
+		}
+	}
+	e.Frame = strings.TrimSuffix(frame.String(), "\n")
+	return e
+}
@@ -0,0 +1,36 @@
+package d2_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Darkness4/blog/d2"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/text"
+)
+
+func TestErrors(t *testing.T) {
+	source := []byte("# Title\n\n- item\n\n  ```d2\n  a -> b\n  a: {shape: nope}\n  ```\n\n```d2 {theme=999}\nx -> y\n```\n")
+	md := goldmark.New(goldmark.WithExtensions(&d2.Extender{}))
+	doc := md.Parser().Parse(text.NewReader(source))
+	var buf bytes.Buffer
+	if err := md.Renderer().Render(&buf, source, doc); err != nil {
+		t.Fatal(err)
+	}
+
+	errs := d2.Errors(doc)
+	expected := []struct {
+		line, column int
+	}{
+		{line: 7, column: 14},
+		{line: 10, column: 1},
+	}
+	if len(errs) != len(expected) {
+		t.Fatalf("expected %d errors, got %v", len(expected), errs)
+	}
+	for i, e := range expected {
+		if errs[i].Line != e.line || errs[i].Column != e.column {
+			t.Errorf("expected error at %d:%d, got %v", e.line, e.column, errs[i])
+		}
+	}
+}
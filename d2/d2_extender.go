@@ -10,6 +10,9 @@ import (
 type Extender struct {
 	CompileOptions
 	RenderOptions
+	// AssetURL returns the URL of an asset from its name. If set, the
+	// diagrams are not inlined but linked to external SVG files, see Assets.
+	AssetURL func(name string) string
 }
 
 func (e *Extender) Extend(m goldmark.Markdown) {
@@ -20,6 +23,7 @@ func (e *Extender) Extend(m goldmark.Markdown) {
 		util.Prioritized(&HTMLRenderer{
 			CompileOptions: e.CompileOptions,
 			RenderOptions:  e.RenderOptions,
+			AssetURL:       e.AssetURL,
 		}, 0),
 	))
 }
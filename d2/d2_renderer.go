@@ -3,9 +3,11 @@ package d2
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"log/slog"
 	"os"
 
+	"github.com/Darkness4/blog/utils/blog"
 	"github.com/yuin/goldmark/ast"
 	"github.com/yuin/goldmark/parser"
 	"github.com/yuin/goldmark/renderer"
@@ -16,6 +18,7 @@ import (
 	"oss.terrastruct.com/d2/d2layouts/d2elklayout"
 	"oss.terrastruct.com/d2/d2lib"
 	"oss.terrastruct.com/d2/d2renderers/d2svg"
+	"oss.terrastruct.com/d2/d2themes/d2themescatalog"
 	"oss.terrastruct.com/d2/lib/log"
 	"oss.terrastruct.com/d2/lib/textmeasure"
 )
@@ -26,6 +29,9 @@ type RenderOptions d2svg.RenderOpts
 type HTMLRenderer struct {
 	CompileOptions
 	RenderOptions
+	// AssetURL returns the URL of an asset from its name. If set, the
+	// diagrams are not inlined but linked to external SVG files, see Assets.
+	AssetURL func(name string) string
 }
 
 func (r *HTMLRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
@@ -75,6 +81,131 @@ func getAttributes(infostr []byte) *immutableAttributes {
 	return nil
 }
 
+// Options are the options of a diagram. They are set in the front-matter of
+// the page (see OptionsKey) and overridden per block in the info string:
+//
+//	```d2 {layout="elk" theme=0 dark-theme=200 sketch=true pad=25 scale=0.9 center=true}
+//
+// The diagrams with several boards (layers, scenarios or steps) are rendered
+// as a stepper, or as an animated SVG with animate=<milliseconds per board>.
+type Options = blog.DiagramOptions
+
+// parseOptions overrides opts with the attributes of a block, and returns the
+// title of the block.
+func parseOptions(attrs *immutableAttributes, opts *Options) (title string, err error) {
+	if attrs == nil {
+		return "", nil
+	}
+	for _, attr := range attrs.All() {
+		name := string(attr.Name)
+		switch name {
+		case "layout":
+			v, ok := attr.Value.([]uint8)
+			if !ok || (string(v) != "dagre" && string(v) != "elk") {
+				return "", fmt.Errorf("%s: expected dagre or elk", name)
+			}
+			opts.Layout = string(v)
+		case "title":
+			v, ok := attr.Value.([]uint8)
+			if !ok {
+				return "", fmt.Errorf("%s: expected a string", name)
+			}
+			title = string(v)
+		case "theme", "dark-theme", "pad", "animate":
+			v, ok := attr.Value.(float64)
+			if !ok || v < 0 || v != float64(int64(v)) {
+				return "", fmt.Errorf("%s: expected a non-negative integer", name)
+			}
+			i := int64(v)
+			switch name {
+			case "theme":
+				opts.Theme = &i
+			case "dark-theme":
+				opts.DarkTheme = &i
+			case "pad":
+				opts.Pad = &i
+			case "animate":
+				opts.Animate = &i
+			}
+		case "scale":
+			v, ok := attr.Value.(float64)
+			if !ok || v <= 0 {
+				return "", fmt.Errorf("%s: expected a positive number", name)
+			}
+			opts.Scale = &v
+		case "sketch", "center":
+			v, ok := attr.Value.(bool)
+			if !ok {
+				return "", fmt.Errorf("%s: expected a boolean", name)
+			}
+			if name == "sketch" {
+				opts.Sketch = &v
+			} else {
+				opts.Center = &v
+			}
+		default:
+			return "", fmt.Errorf("%s: unknown attribute", name)
+		}
+	}
+	return title, nil
+}
+
+// apply overrides the render options with the options that are set.
+func apply(renderOpts *d2svg.RenderOpts, opts Options) error {
+	for _, id := range []*int64{opts.Theme, opts.DarkTheme} {
+		if id != nil && d2themescatalog.Find(*id).Name == "" {
+			return fmt.Errorf("unknown theme %d", *id)
+		}
+	}
+	if opts.Theme != nil {
+		renderOpts.ThemeID = opts.Theme
+	}
+	if opts.DarkTheme != nil {
+		renderOpts.DarkThemeID = opts.DarkTheme
+	}
+	if opts.Sketch != nil {
+		renderOpts.Sketch = opts.Sketch
+	}
+	if opts.Pad != nil {
+		renderOpts.Pad = opts.Pad
+	}
+	if opts.Scale != nil {
+		renderOpts.Scale = opts.Scale
+	}
+	if opts.Center != nil {
+		renderOpts.Center = opts.Center
+	}
+	return nil
+}
+
+// variant is a rendering of a diagram with a theme.
+type variant struct {
+	// class is the class of the element wrapping the SVG, if any.
+	class string
+	theme *int64
+}
+
+// variants returns the renderings of a diagram: one per theme if it has a dark
+// theme, the CSS shows the one matching prefers-color-scheme.
+func variants(renderOpts d2svg.RenderOpts) []variant {
+	if renderOpts.DarkThemeID == nil {
+		return []variant{{theme: renderOpts.ThemeID}}
+	}
+	return []variant{
+		{class: "d2-light", theme: renderOpts.ThemeID},
+		{class: "d2-dark", theme: renderOpts.DarkThemeID},
+	}
+}
+
+// writeSource writes the source of a diagram that failed to render.
+func writeSource(w util.BufWriter, source []byte) {
+	_, _ = w.WriteString("<pre>")
+	_, _ = w.Write(util.EscapeHTML(source))
+	_, _ = w.WriteString("</pre>")
+}
+
+// Render renders a diagram. The errors of the diagram are not returned but
+// kept in the block, see Errors.
 func (r *HTMLRenderer) Render(
 	w util.BufWriter,
 	src []byte,
@@ -108,27 +239,27 @@ func (r *HTMLRenderer) Render(
 		compileOpts.Ruler = ruler
 	}
 	// Parsing Info ```d2 ({key=value}) <-- THIS
-	var title string
-	if attrs := getAttributes(n.Info()); attrs != nil {
-		if layoutAttr, ok := attrs.GetString("layout"); ok {
-			if layoutAttrB, ok := layoutAttr.([]uint8); ok {
-				layoutAttrStr := string(layoutAttrB)
-				switch layoutAttrStr {
-				case "dagre":
-					compileOpts.LayoutResolver = func(_ string) (d2graph.LayoutGraph, error) {
-						return d2dagrelayout.DefaultLayout, nil
-					}
-				case "elk":
-					compileOpts.LayoutResolver = func(_ string) (d2graph.LayoutGraph, error) {
-						return d2elklayout.DefaultLayout, nil
-					}
-				}
-			}
+	opts := n.Options
+	title, err := parseOptions(getAttributes(n.Info()), &opts)
+	if err != nil {
+		n.errs = []*Error{n.newError(-1, 0, err, src)}
+		writeSource(w, b.Bytes())
+		return ast.WalkContinue, nil
+	}
+	renderOpts := d2svg.RenderOpts(r.RenderOptions)
+	if err := apply(&renderOpts, opts); err != nil {
+		n.errs = []*Error{n.newError(-1, 0, err, src)}
+		writeSource(w, b.Bytes())
+		return ast.WalkContinue, nil
+	}
+	switch opts.Layout {
+	case "elk":
+		compileOpts.LayoutResolver = func(_ string) (d2graph.LayoutGraph, error) {
+			return d2elklayout.DefaultLayout, nil
 		}
-		if titleAttr, ok := attrs.GetString("title"); ok {
-			if titleAttrB, ok := titleAttr.([]uint8); ok {
-				title = string(titleAttrB)
-			}
+	case "dagre":
+		compileOpts.LayoutResolver = func(_ string) (d2graph.LayoutGraph, error) {
+			return d2dagrelayout.DefaultLayout, nil
 		}
 	}
 
@@ -137,41 +268,134 @@ func (r *HTMLRenderer) Render(
 			return d2dagrelayout.DefaultLayout, nil
 		}
 	}
-	renderOpts := d2svg.RenderOpts(r.RenderOptions)
+	vs := variants(renderOpts)
+	// The diagram is laid out once, and rendered with the theme of every
+	// variant, as the d2 CLI does for its dark theme.
+	layoutOpts := renderOpts
+	layoutOpts.DarkThemeID = nil
 	diagram, _, err := d2lib.Compile(
 		log.With(context.Background(), slog.New(slog.NewTextHandler(os.Stderr, nil))),
 		b.String(),
 		&compileOpts,
-		&renderOpts,
+		&layoutOpts,
 	)
 	if err != nil {
-		_, _ = w.Write(b.Bytes())
-		return ast.WalkContinue, err
+		n.errs = newErrors(err, n, src)
+		writeSource(w, b.Bytes())
+		return ast.WalkContinue, nil
 	}
-	out, err := d2svg.Render(diagram, &renderOpts)
-	if err != nil {
-		_, _ = w.Write(b.Bytes())
-		return ast.WalkContinue, err
+	// boards[i][j] is the board j rendered with the variant i.
+	boards := make([][][]byte, 0, len(vs))
+	var names []string
+	for _, v := range vs {
+		variantOpts := layoutOpts
+		variantOpts.ThemeID = v.theme
+		variantOpts.DarkThemeID = nil
+		if v.class != "" {
+			// The IDs of the inlined SVGs must be unique in the page.
+			variantOpts.Salt = &v.class
+		}
+		var svgs [][]byte
+		names, svgs, err = renderBoards(diagram, variantOpts, opts.Animate)
+		if err != nil {
+			n.errs = newErrors(err, n, src)
+			writeSource(w, b.Bytes())
+			return ast.WalkContinue, nil
+		}
+		boards = append(boards, svgs)
 	}
-	_, err = w.Write(out)
-	if err != nil {
-		_, _ = w.Write(b.Bytes())
-		return ast.WalkContinue, err
+
+	if len(names) == 1 {
+		urls := r.writeBoard(w, n, vs, boards, 0, title)
+		writeCaption(w, title, vs, urls)
+		return ast.WalkContinue, nil
 	}
-	_, err = w.WriteString("<figcaption><i>")
-	if err != nil {
-		_, _ = w.Write(b.Bytes())
-		return ast.WalkContinue, err
+
+	_, _ = w.WriteString(`<div class="d2-stepper">`)
+	for j, name := range names {
+		_, _ = w.WriteString(`<div class="d2-board" data-name="`)
+		_, _ = w.Write(util.EscapeHTML([]byte(name)))
+		_ = w.WriteByte('"')
+		if j > 0 {
+			_, _ = w.WriteString(" hidden")
+		}
+		_ = w.WriteByte('>')
+		if urls := r.writeBoard(w, n, vs, boards, j, boardAlt(title, name)); urls != nil {
+			_, _ = w.WriteString(`<p class="d2-full-size">`)
+			writeFullSizeLinks(w, vs, urls)
+			_, _ = w.WriteString("</p>")
+		}
+		_, _ = w.WriteString("</div>")
 	}
-	_, err = w.WriteString(title)
-	if err != nil {
-		_, _ = w.Write(b.Bytes())
-		return ast.WalkContinue, err
+	_, _ = w.WriteString(`<nav class="d2-stepper-nav">`)
+	_, _ = w.WriteString(`<button type="button" aria-label="Previous board" disabled
+		_="on click call d2Step(closest .d2-stepper, -1)">«</button>`)
+	_, _ = w.WriteString(`<span class="d2-stepper-label" aria-live="polite">`)
+	_, _ = w.Write(util.EscapeHTML([]byte(boardLabel(0, len(names), names[0]))))
+	_, _ = w.WriteString(`</span>`)
+	_, _ = w.WriteString(`<button type="button" aria-label="Next board"
+		_="on click call d2Step(closest .d2-stepper, 1)">»</button>`)
+	_, _ = w.WriteString(`</nav></div>`)
+	writeCaption(w, title, vs, nil)
+	return ast.WalkContinue, nil
+}
+
+// writeBoard writes a board of a diagram in every variant, and returns the
+// URLs of the assets if the diagrams are external.
+func (r *HTMLRenderer) writeBoard(
+	w util.BufWriter,
+	n *Block,
+	vs []variant,
+	boards [][][]byte,
+	j int,
+	alt string,
+) []string {
+	outs := make([][]byte, len(vs))
+	for i := range vs {
+		outs[i] = boards[i][j]
 	}
-	_, err = w.WriteString("</i></figcaption>")
-	if err != nil {
-		_, _ = w.Write(b.Bytes())
-		return ast.WalkContinue, err
+	if r.AssetURL != nil {
+		return r.writePicture(w, n, vs, outs, alt)
+	}
+	for i, out := range outs {
+		if vs[i].class != "" {
+			_, _ = w.WriteString(`<div class="` + vs[i].class + `">`)
+			_, _ = w.Write(out)
+			_, _ = w.WriteString("</div>")
+		} else {
+			_, _ = w.Write(out)
+		}
+	}
+	return nil
+}
+
+// writeCaption writes the caption of a diagram, with the links to the full
+// size diagram if external. Nothing is written if there is no caption.
+func writeCaption(w util.BufWriter, title string, vs []variant, urls []string) {
+	if title == "" && len(urls) == 0 {
+		return
+	}
+	_, _ = w.WriteString("<figcaption>")
+	if title != "" {
+		_, _ = w.WriteString("<i>")
+		_, _ = w.Write(util.EscapeHTML([]byte(title)))
+		_, _ = w.WriteString("</i>")
+	}
+	if len(urls) > 0 {
+		if title != "" {
+			_, _ = w.WriteString(" · ")
+		}
+		writeFullSizeLinks(w, vs, urls)
+	}
+	_, _ = w.WriteString("</figcaption>")
+}
+
+func writeFullSizeLinks(w util.BufWriter, vs []variant, urls []string) {
+	for i, url := range urls {
+		_, _ = w.WriteString(`<a href="` + url + `" target="_blank"`)
+		if vs[i].class != "" {
+			_, _ = w.WriteString(` class="` + vs[i].class + `"`)
+		}
+		_, _ = w.WriteString(">View full size</a>")
 	}
-	return ast.WalkContinue, err
 }
@@ -0,0 +1,61 @@
+package d2_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/Darkness4/blog/d2"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/text"
+)
+
+func TestRenderCaption(t *testing.T) {
+	tests := []struct {
+		title    string
+		source   string
+		assets   bool
+		expected string
+	}{
+		{title: "No caption", source: "```d2\na -> b\n```\n"},
+		{
+			title:    "Escaped title",
+			source:   "```d2 {title=\"<b>A & B</b>\"}\na -> b\n```\n",
+			expected: "<figcaption><i>&lt;b&gt;A &amp; B&lt;/b&gt;</i></figcaption>",
+		},
+		{
+			title:    "Full size link",
+			source:   "```d2\na -> b\n```\n",
+			assets:   true,
+			expected: `<figcaption><a href="/diagram.`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			ext := &d2.Extender{}
+			if tt.assets {
+				ext.AssetURL = func(name string) string { return "/" + name }
+			}
+			md := goldmark.New(goldmark.WithExtensions(ext))
+			source := []byte(tt.source)
+			doc := md.Parser().Parse(text.NewReader(source))
+			var buf bytes.Buffer
+			if err := md.Renderer().Render(&buf, source, doc); err != nil {
+				t.Fatal(err)
+			}
+			if errs := d2.Errors(doc); len(errs) > 0 {
+				t.Fatal(errs)
+			}
+			out := buf.String()
+			if tt.expected == "" {
+				if strings.Contains(out, "<figcaption") {
+					t.Errorf("expected no caption, got %s", out)
+				}
+				return
+			}
+			if !strings.Contains(out, tt.expected) {
+				t.Errorf("expected %s, got %s", tt.expected, out)
+			}
+		})
+	}
+}
@@ -13,7 +13,11 @@ type Transformer struct {
 
 var _d2 = []byte("d2")
 
-func (s *Transformer) Transform(doc *ast.Document, reader text.Reader, _ parser.Context) {
+// OptionsKey is the parser context key of the default Options of the diagrams
+// of the page.
+var OptionsKey = parser.NewContextKey()
+
+func (s *Transformer) Transform(doc *ast.Document, reader text.Reader, pc parser.Context) {
 	var blocks []*ast.FencedCodeBlock
 	source := reader.Source()
 
@@ -42,8 +46,10 @@ func (s *Transformer) Transform(doc *ast.Document, reader text.Reader, _ parser.
 		return
 	}
 
+	defaults, _ := pc.Get(OptionsKey).(Options)
 	for _, cb := range blocks {
 		b := new(Block)
+		b.Options = defaults
 		b.SetInfo(cb.Info.Segment.Value(source))
 		b.SetLines(cb.Lines())
 
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/Darkness4/blog/web"
+	"github.com/Darkness4/blog/web/devserver"
+	"github.com/Darkness4/blog/web/gen/index"
+	"github.com/Darkness4/blog/web/preview"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog/hlog"
+	"github.com/rs/zerolog/log"
+)
+
+// webDir is the directory of the web package, from the root of the repository.
+const webDir = "web"
+
+// runDev serves the pages from the sources of the repository.
+//
+// The generator runs in the background: it builds every page, then rebuilds
+// the pages whose sources or templates changed, and streams them with the
+// index to the server, which serves them from memory and reloads the
+// browsers. The removed pages are removed from the server too. Nothing is
+// written in the working tree. The base templates and the static files are
+// read from the disk on each request.
+//
+// The languages are compiled in the server: a new language needs a restart.
+//
+// The unpublished pages are previewed, and the page views are not counted.
+func runDev(ctx context.Context) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// The generator is built first rather than run with "go run", so that it
+	// receives the interruption.
+	tmp, err := os.MkdirTemp("", "blog-dev-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+	bin, err := filepath.Abs(filepath.Join(tmp, "generator"))
+	if err != nil {
+		return err
+	}
+	build := exec.CommandContext(ctx, "go", "build", "-tags", "build", "-o", bin, "build.go")
+	build.Dir = webDir
+	build.Stdout, build.Stderr = os.Stdout, os.Stderr
+	if err := build.Run(); err != nil {
+		return fmt.Errorf("failed to build the generator: %w", err)
+	}
+
+	gen := exec.CommandContext(ctx, bin, "dev")
+	gen.Dir = webDir
+	gen.Stderr = os.Stderr
+	gen.Cancel = func() error {
+		return gen.Process.Signal(os.Interrupt)
+	}
+	gen.WaitDelay = 10 * time.Second
+	out, err := gen.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := gen.Start(); err != nil {
+		return err
+	}
+
+	overlay := devserver.NewOverlay(os.DirFS(webDir))
+	reloader := devserver.NewReloader()
+	go func() {
+		err := overlay.Apply(out, func() {
+			if err := loadIndex(overlay); err != nil {
+				log.Err(err).Msg("failed to load the index")
+			}
+			reloader.Reload()
+		})
+		if err != nil {
+			log.Err(err).Msg("failed to read the generated pages")
+		}
+	}()
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return err
+	}
+	pages := web.NewPages(overlay, publicURL, secret)
+
+	r := chi.NewRouter()
+	r.Use(hlog.NewHandler(log.Logger))
+	r.Handle(devserver.EventsPath, reloader)
+	r.Handle("/static/*", web.StaticFunc(overlay))
+	r.Post(web.ViewsPath+"/*", web.ViewsFunc(nil, nil))
+	r.Handle("/*", reloader.Inject(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if entry, ok := web.LookupPage(filepath.Clean(r.URL.Path)); ok && !entry.Published(time.Now()) {
+			q := r.URL.Query()
+			q.Set(preview.QueryParam, preview.Sign(secret, entry.Href, time.Now().Add(time.Hour)))
+			r.URL.RawQuery = q.Encode()
+		}
+		// The pages are rendered on each request, as the templates are read
+		// from the disk.
+		pages.Reset()
+		pages.ServeHTTP(w, r)
+	})))
+
+	srv := &http.Server{Addr: listenAddress, Handler: r}
+	go func() {
+		<-ctx.Done()
+		_ = srv.Close()
+	}()
+	log.Info().Str("listenAddress", listenAddress).Msg("listening")
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	if err := gen.Wait(); err != nil && ctx.Err() == nil {
+		return err
+	}
+	return nil
+}
+
+// loadIndex serves the entries of the index of the last build of the
+// generator, if any.
+func loadIndex(fsys fs.FS) error {
+	b, err := fs.ReadFile(fsys, devserver.IndexName)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var entries []index.Index
+	if err := json.Unmarshal(b, &entries); err != nil {
+		return err
+	}
+	index.SetEntries(entries)
+	return nil
+}
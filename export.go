@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Darkness4/blog/web"
+	"github.com/Darkness4/blog/web/gen/index"
+	"github.com/rs/zerolog/log"
+)
+
+// runExport writes the blog to dir, to be served by a static host.
+//
+// The pages are written with base.html, so the HTMX navigations load the full
+// pages. The view counter and the search are not served, and are not shown.
+func runExport(dir string) error {
+	var fsys fs.FS = web.Embedded()
+	if contentDir != "" {
+		var err error
+		if fsys, err = web.LoadDir(contentDir); err != nil {
+			return err
+		}
+	}
+	n, err := web.NewPages(fsys, publicURL, nil).Export(dir)
+	if err != nil {
+		return err
+	}
+
+	files := map[string]func(io.Writer) error{
+		"sitemap.xml": writeSitemap,
+		"robots.txt":  writeRobots,
+	}
+	entries := index.ListedAt(time.Now())
+	for _, lang := range index.Languages {
+		prefix := index.LanguagePrefix(lang)
+		for _, format := range feedFormats {
+			files[prefix+"/"+format.name] = func(w io.Writer) error {
+				return format.write(newFeed(fsys, lang, 0), w)
+			}
+			for _, tag := range index.TagsOf(index.InLanguage(entries, lang)) {
+				files[prefix+"/tags/"+tag.Slug+"/"+format.name] = func(w io.Writer) error {
+					feed, _ := newTagFeed(fsys, lang, tag.Slug, 0)
+					return format.write(feed, w)
+				}
+			}
+		}
+	}
+	for name, write := range files {
+		var buf bytes.Buffer
+		if err := write(&buf); err != nil {
+			return err
+		}
+		fpath := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(fpath), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(fpath, buf.Bytes(), 0o644); err != nil {
+			return err
+		}
+	}
+
+	log.Info().Str("dir", dir).Int("pages", n).Int("files", len(files)).Msg("exported")
+	return nil
+}
@@ -6,6 +6,7 @@ package images
 import (
 	"bytes"
 	"io"
+	"strings"
 
 	"github.com/yuin/goldmark"
 	"github.com/yuin/goldmark/ast"
@@ -68,6 +69,10 @@ func (r *Replacer) renderImage(
 		// 	return ast.WalkSkipChildren, nil
 		// }
 		n.Destination = util.StringToReadOnlyBytes(src)
+
+		if srcset, ok := n.AttributeString("srcset"); ok {
+			n.SetAttribute([]byte("srcset"), []byte(r.replaceSrcset(string(srcset.([]byte)))))
+		}
 	}
 
 	if _, err := w.WriteString("<img src=\""); err != nil {
@@ -110,6 +115,16 @@ func (r *Replacer) renderImage(
 	return ast.WalkSkipChildren, nil
 }
 
+// replaceSrcset replaces the links of the image candidates of a srcset.
+func (r *Replacer) replaceSrcset(srcset string) string {
+	candidates := strings.Split(srcset, ",")
+	for i, c := range candidates {
+		link, descriptor, _ := strings.Cut(strings.TrimSpace(c), " ")
+		candidates[i] = strings.TrimSpace(r.ReplaceFunc(link) + " " + descriptor)
+	}
+	return strings.Join(candidates, ", ")
+}
+
 func buildTextOf(n ast.Node, src []byte, b io.Writer) {
 	for c := n.FirstChild(); c != nil; c = c.NextSibling() {
 		if t, ok := c.(*ast.Text); ok {
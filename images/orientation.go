@@ -0,0 +1,156 @@
+package images
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+)
+
+// exifHeader starts the APP1 segments holding EXIF data.
+var exifHeader = []byte("Exif\x00\x00")
+
+// orientationTag is the EXIF tag of the orientation.
+const orientationTag = 0x0112
+
+// decodeConfig returns the color model, the dimensions and the format of an
+// image. The dimensions are the ones of the image as displayed, after its EXIF
+// orientation.
+func decodeConfig(b []byte) (image.Config, string, error) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(b))
+	if err != nil {
+		return config, format, err
+	}
+	if format == "jpeg" && transposed(jpegOrientation(b)) {
+		config.Width, config.Height = config.Height, config.Width
+	}
+	return config, format, nil
+}
+
+// transposed returns true if an image with the EXIF orientation o is displayed
+// with its width and height swapped.
+func transposed(o int) bool {
+	return o >= 5 && o <= 8
+}
+
+// jpegOrientation returns the EXIF orientation of a JPEG file, from 1 to 8. It
+// is 1 (as is) if the file has no valid orientation.
+func jpegOrientation(b []byte) int {
+	if len(b) < 2 || b[0] != 0xff || b[1] != 0xd8 {
+		return 1
+	}
+	for rest := b[2:]; len(rest) >= 4 && rest[0] == 0xff; {
+		marker := rest[1]
+		length := int(binary.BigEndian.Uint16(rest[2:4]))
+		if marker == 0xda || length < 2 || length+2 > len(rest) {
+			break
+		}
+		payload := rest[4 : length+2]
+		rest = rest[length+2:]
+		if marker != 0xe1 || !bytes.HasPrefix(payload, exifHeader) {
+			continue
+		}
+		if o := exifOrientation(payload[len(exifHeader):]); o != 0 {
+			return o
+		}
+	}
+	return 1
+}
+
+// exifOrientation returns the orientation in the first IFD of the TIFF
+// structure of an EXIF segment, or 0 if there is none.
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 0
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 0
+	}
+	if order.Uint16(tiff[2:4]) != 42 {
+		return 0
+	}
+	offset := int64(order.Uint32(tiff[4:8]))
+	if offset+2 > int64(len(tiff)) {
+		return 0
+	}
+	ifd := tiff[offset:]
+	count := int(order.Uint16(ifd[:2]))
+	for i := range count {
+		if 2+12*(i+1) > len(ifd) {
+			return 0
+		}
+		entry := ifd[2+12*i:]
+		// The orientation is a single SHORT, stored in the value field.
+		if order.Uint16(entry[:2]) != orientationTag || order.Uint16(entry[2:4]) != 3 {
+			continue
+		}
+		if o := int(order.Uint16(entry[8:10])); o >= 1 && o <= 8 {
+			return o
+		}
+		return 0
+	}
+	return 0
+}
+
+// orientationSegment returns an APP1 segment holding an EXIF structure with
+// the orientation o only.
+func orientationSegment(o int) []byte {
+	const length = 2 + 6 + 8 + 2 + 12 + 4
+	seg := make([]byte, 0, length+2)
+	seg = append(seg, 0xff, 0xe1)
+	seg = binary.BigEndian.AppendUint16(seg, length)
+	seg = append(seg, exifHeader...)
+	// TIFF header, big-endian, with the first IFD right after it.
+	seg = append(seg, 'M', 'M', 0, 42, 0, 0, 0, 8)
+	seg = binary.BigEndian.AppendUint16(seg, 1)
+	seg = binary.BigEndian.AppendUint16(seg, orientationTag)
+	seg = binary.BigEndian.AppendUint16(seg, 3)
+	seg = binary.BigEndian.AppendUint32(seg, 1)
+	seg = binary.BigEndian.AppendUint16(seg, uint16(o))
+	seg = append(seg, 0, 0)
+	// No next IFD.
+	return binary.BigEndian.AppendUint32(seg, 0)
+}
+
+// orient returns src transformed as displayed with the EXIF orientation o.
+func orient(src image.Image, o int) image.Image {
+	if o <= 1 || o > 8 {
+		return src
+	}
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+	dw, dh := w, h
+	if transposed(o) {
+		dw, dh = h, w
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, dw, dh))
+	var at func(x, y int) (int, int)
+	switch o {
+	case 2:
+		at = func(x, y int) (int, int) { return w - 1 - x, y }
+	case 3:
+		at = func(x, y int) (int, int) { return w - 1 - x, h - 1 - y }
+	case 4:
+		at = func(x, y int) (int, int) { return x, h - 1 - y }
+	case 5:
+		at = func(x, y int) (int, int) { return y, x }
+	case 6:
+		at = func(x, y int) (int, int) { return y, h - 1 - x }
+	case 7:
+		at = func(x, y int) (int, int) { return w - 1 - y, h - 1 - x }
+	case 8:
+		at = func(x, y int) (int, int) { return w - 1 - y, x }
+	}
+	for y := range dh {
+		for x := range dw {
+			sx, sy := at(x, y)
+			dst.Set(x, y, src.At(b.Min.X+sx, b.Min.Y+sy))
+		}
+	}
+	return dst
+}
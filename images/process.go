@@ -0,0 +1,176 @@
+package images
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"path"
+
+	"golang.org/x/image/draw"
+)
+
+// jpegQuality is the quality of the resized JPEG variants.
+const jpegQuality = 85
+
+var pngEncoder = png.Encoder{CompressionLevel: png.BestCompression}
+
+// Process strips the metadata (EXIF, GPS, comments...) from the image at name
+// and generates its resized variants.
+//
+// It returns the published files, keyed by their base name. Variants that are
+// not lighter than the image are dropped. The image itself is stripped
+// losslessly and keeps its EXIF orientation, which is applied to the pixels of
+// the variants. Formats other than PNG and JPEG are returned as is.
+func Process(name string, b []byte) (map[string][]byte, error) {
+	base := path.Base(name)
+	config, format, err := decodeConfig(b)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	var stripped []byte
+	switch format {
+	case "png":
+		stripped, err = stripPNG(b)
+	case "jpeg":
+		stripped, err = stripJPEG(b)
+	default:
+		return map[string][]byte{base: b}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	files := map[string][]byte{base: stripped}
+
+	widths := variantWidths(format, config.Width)
+	if len(widths) == 0 {
+		return files, nil
+	}
+	src, _, err := image.Decode(bytes.NewReader(b))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	orientation := 1
+	if format == "jpeg" {
+		orientation = jpegOrientation(b)
+	}
+	bounds := src.Bounds()
+	for _, w := range widths {
+		h := config.Height * w / config.Width
+		// The image is scaled before being oriented, which is cheaper.
+		sw, sh := w, h
+		if transposed(orientation) {
+			sw, sh = h, w
+		}
+		var dst draw.Image = image.NewNRGBA(image.Rect(0, 0, sw, sh))
+		// Screenshots are often paletted and compress better that way.
+		if p, ok := src.(*image.Paletted); ok {
+			dst = image.NewPaletted(image.Rect(0, 0, sw, sh), p.Palette)
+		}
+		draw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, draw.Src, nil)
+		variant := orient(dst, orientation)
+
+		var buf bytes.Buffer
+		switch format {
+		case "png":
+			err = pngEncoder.Encode(&buf, variant)
+		case "jpeg":
+			err = jpeg.Encode(&buf, variant, &jpeg.Options{Quality: jpegQuality})
+		default:
+			err = fmt.Errorf("unsupported format: %s", format)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		// A variant is useless if it is not lighter than the image.
+		if buf.Len() < len(stripped) {
+			files[Variant(base, w)] = buf.Bytes()
+		}
+	}
+	return files, nil
+}
+
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// pngMetadata are the ancillary chunks removed from PNG files.
+var pngMetadata = map[string]bool{
+	"tEXt": true,
+	"zTXt": true,
+	"iTXt": true,
+	"eXIf": true,
+	"tIME": true,
+}
+
+// stripPNG removes the metadata chunks of a PNG file.
+func stripPNG(b []byte) ([]byte, error) {
+	if !bytes.HasPrefix(b, pngSignature) {
+		return nil, errors.New("invalid png signature")
+	}
+	out := make([]byte, 0, len(b))
+	out = append(out, pngSignature...)
+	for rest := b[len(pngSignature):]; len(rest) > 0; {
+		if len(rest) < 12 {
+			return nil, errors.New("truncated png chunk")
+		}
+		length := binary.BigEndian.Uint32(rest[:4])
+		if uint64(length)+12 > uint64(len(rest)) {
+			return nil, errors.New("truncated png chunk")
+		}
+		chunk := rest[:length+12]
+		rest = rest[length+12:]
+		typ := string(chunk[4:8])
+		if pngMetadata[typ] {
+			continue
+		}
+		if crc32.ChecksumIEEE(chunk[4:length+8]) != binary.BigEndian.Uint32(chunk[length+8:]) {
+			return nil, fmt.Errorf("invalid png chunk checksum: %s", typ)
+		}
+		out = append(out, chunk...)
+	}
+	return out, nil
+}
+
+// stripJPEG removes the APP1 (EXIF, XMP), APP13 (IPTC) and COM segments of a
+// JPEG file. The EXIF orientation is kept in a minimal APP1 segment, after the
+// APP0 (JFIF) segment if any.
+func stripJPEG(b []byte) ([]byte, error) {
+	if len(b) < 2 || b[0] != 0xff || b[1] != 0xd8 {
+		return nil, errors.New("invalid jpeg marker")
+	}
+	var exif []byte
+	if o := jpegOrientation(b); o != 1 {
+		exif = orientationSegment(o)
+	}
+	out := make([]byte, 0, len(b))
+	out = append(out, b[:2]...)
+	rest := b[2:]
+	for {
+		if len(rest) < 4 || rest[0] != 0xff {
+			return nil, errors.New("invalid jpeg segment")
+		}
+		marker := rest[1]
+		length := int(binary.BigEndian.Uint16(rest[2:4]))
+		if length < 2 || length+2 > len(rest) {
+			return nil, errors.New("truncated jpeg segment")
+		}
+		segment := rest[:length+2]
+		rest = rest[length+2:]
+		if marker != 0xe0 && exif != nil {
+			out = append(out, exif...)
+			exif = nil
+		}
+		switch marker {
+		case 0xe1, 0xed, 0xfe:
+			continue
+		case 0xda:
+			// Start of scan: the rest is the compressed data.
+			out = append(out, segment...)
+			return append(out, rest...), nil
+		}
+		out = append(out, segment...)
+	}
+}
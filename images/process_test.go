@@ -0,0 +1,283 @@
+package images
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+// newJPEG returns a w x h JPEG file with the segments inserted after its SOI.
+func newJPEG(t *testing.T, w, h int, segments ...[]byte) []byte {
+	t.Helper()
+	// The pixels are noisy, so that the variants are lighter than the image.
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	seed := uint32(1)
+	for i := range img.Pix {
+		seed = seed*1664525 + 1013904223
+		img.Pix[i] = uint8(seed >> 24)
+	}
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, nil); err != nil {
+		t.Fatal(err)
+	}
+	b := buf.Bytes()
+	out := append([]byte{}, b[:2]...)
+	for _, s := range segments {
+		out = append(out, s...)
+	}
+	return append(out, b[2:]...)
+}
+
+// segment returns a JPEG segment.
+func segment(marker byte, payload string) []byte {
+	s := []byte{0xff, marker}
+	s = binary.BigEndian.AppendUint16(s, uint16(len(payload)+2))
+	return append(s, payload...)
+}
+
+// exifSegment returns an APP1 segment with a little-endian EXIF structure
+// holding a description and the orientation o.
+func exifSegment(o int) []byte {
+	tiff := []byte{'I', 'I', 42, 0, 8, 0, 0, 0}
+	tiff = binary.LittleEndian.AppendUint16(tiff, 2)
+	// ImageDescription, ASCII, stored at offset 38.
+	tiff = binary.LittleEndian.AppendUint16(tiff, 0x010e)
+	tiff = binary.LittleEndian.AppendUint16(tiff, 2)
+	tiff = binary.LittleEndian.AppendUint32(tiff, 6)
+	tiff = binary.LittleEndian.AppendUint32(tiff, 38)
+	tiff = binary.LittleEndian.AppendUint16(tiff, orientationTag)
+	tiff = binary.LittleEndian.AppendUint16(tiff, 3)
+	tiff = binary.LittleEndian.AppendUint32(tiff, 1)
+	tiff = binary.LittleEndian.AppendUint32(tiff, uint32(o))
+	tiff = binary.LittleEndian.AppendUint32(tiff, 0)
+	tiff = append(tiff, "GPS 1\x00"...)
+	return segment(0xe1, string(exifHeader)+string(tiff))
+}
+
+func TestStripJPEG(t *testing.T) {
+	jfif := segment(0xe0, "JFIF\x00\x01\x01\x00\x00\x01\x00\x01\x00\x00")
+	tests := []struct {
+		title       string
+		input       []byte
+		orientation int
+		removed     []string
+	}{
+		{
+			title:   "Metadata",
+			input:   newJPEG(t, 8, 8, segment(0xe1, "http://ns.adobe.com/xap/1.0/\x00<x/>"), segment(0xed, "Photoshop 3.0\x00"), segment(0xfe, "comment")),
+			removed: []string{"http://ns.adobe.com", "Photoshop", "comment"},
+		},
+		{
+			title:       "Orientation",
+			input:       newJPEG(t, 8, 8, exifSegment(6), segment(0xfe, "comment")),
+			orientation: 6,
+			removed:     []string{"GPS", "comment"},
+		},
+		{
+			title:       "Orientation after JFIF",
+			input:       newJPEG(t, 8, 8, jfif, exifSegment(3)),
+			orientation: 3,
+			removed:     []string{"GPS"},
+		},
+		{
+			title:   "Default orientation",
+			input:   newJPEG(t, 8, 8, exifSegment(1)),
+			removed: []string{"GPS", "Exif"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			out, err := stripJPEG(tt.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, r := range tt.removed {
+				if bytes.Contains(out, []byte(r)) {
+					t.Errorf("expected %q to be removed", r)
+				}
+			}
+			expected := max(tt.orientation, 1)
+			if o := jpegOrientation(out); o != expected {
+				t.Errorf("expected orientation %d, got %d", expected, o)
+			}
+			if bytes.HasPrefix(tt.input[2:], jfif) && !bytes.HasPrefix(out[2:], jfif) {
+				t.Error("expected the JFIF segment to stay first")
+			}
+			if _, err := jpeg.Decode(bytes.NewReader(out)); err != nil {
+				t.Errorf("invalid stripped jpeg: %v", err)
+			}
+		})
+	}
+}
+
+func TestStripJPEGInvalid(t *testing.T) {
+	valid := newJPEG(t, 8, 8, exifSegment(6))
+	for title, input := range map[string][]byte{
+		"Empty":             {},
+		"Not a JPEG":        []byte("\x89PNG"),
+		"Only SOI":          valid[:2],
+		"Truncated header":  valid[:4],
+		"Truncated segment": valid[:10],
+		"Invalid segment":   append([]byte{0xff, 0xd8, 0x00}, valid[3:]...),
+		"Invalid length":    append([]byte{0xff, 0xd8, 0xff, 0xe1, 0x00, 0x01}, valid[6:]...),
+	} {
+		t.Run(title, func(t *testing.T) {
+			if _, err := stripJPEG(input); err == nil {
+				t.Error("expected an error")
+			}
+			// The orientation is read without failing.
+			_ = jpegOrientation(input)
+		})
+	}
+}
+
+func TestJPEGOrientation(t *testing.T) {
+	bigEndian := orientationSegment(8)
+	tests := []struct {
+		title    string
+		input    []byte
+		expected int
+	}{
+		{title: "Little-endian", input: newJPEG(t, 8, 8, exifSegment(6)), expected: 6},
+		{title: "Big-endian", input: newJPEG(t, 8, 8, bigEndian), expected: 8},
+		{title: "Without EXIF", input: newJPEG(t, 8, 8), expected: 1},
+		{title: "Invalid orientation", input: newJPEG(t, 8, 8, exifSegment(9)), expected: 1},
+		{title: "Truncated IFD", input: newJPEG(t, 8, 8, segment(0xe1, string(bigEndian[4:len(bigEndian)-10]))), expected: 1},
+		{title: "Invalid TIFF header", input: newJPEG(t, 8, 8, segment(0xe1, string(exifHeader)+"XX\x00\x2a\x00\x00\x00\x08")), expected: 1},
+		{title: "IFD out of bounds", input: newJPEG(t, 8, 8, segment(0xe1, string(exifHeader)+"MM\x00\x2a\xff\x00\x00\x00")), expected: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			if o := jpegOrientation(tt.input); o != tt.expected {
+				t.Errorf("expected %d, got %d", tt.expected, o)
+			}
+		})
+	}
+}
+
+// newPNG returns a w x h PNG file with a tEXt chunk after its IHDR chunk.
+func newPNG(t *testing.T, w, h int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, w, h))); err != nil {
+		t.Fatal(err)
+	}
+	b := buf.Bytes()
+	ihdr := len(pngSignature) + 12 + 13
+	text := []byte("tEXtComment\x00secret")
+	chunk := binary.BigEndian.AppendUint32(nil, uint32(len(text)-4))
+	chunk = append(chunk, text...)
+	chunk = binary.BigEndian.AppendUint32(chunk, crc32.ChecksumIEEE(text))
+	out := append([]byte{}, b[:ihdr]...)
+	out = append(out, chunk...)
+	return append(out, b[ihdr:]...)
+}
+
+func TestStripPNG(t *testing.T) {
+	input := newPNG(t, 8, 8)
+	out, err := stripPNG(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(out, []byte("secret")) {
+		t.Error("expected the tEXt chunk to be removed")
+	}
+	if _, err := png.Decode(bytes.NewReader(out)); err != nil {
+		t.Errorf("invalid stripped png: %v", err)
+	}
+
+	corrupted := bytes.Clone(input)
+	corrupted[len(pngSignature)+8] ^= 0xff
+	for title, input := range map[string][]byte{
+		"Empty":           {},
+		"Not a PNG":       []byte("\xff\xd8\xff"),
+		"Truncated chunk": input[:len(pngSignature)+6],
+		"Truncated data":  input[:len(input)-4],
+		"Invalid CRC":     corrupted,
+	} {
+		t.Run(title, func(t *testing.T) {
+			if _, err := stripPNG(input); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestProcessOrientation(t *testing.T) {
+	input := newJPEG(t, 2000, 1000, exifSegment(6))
+	config, _, err := decodeConfig(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Width != 1000 || config.Height != 2000 {
+		t.Errorf("expected the displayed size 1000x2000, got %dx%d", config.Width, config.Height)
+	}
+
+	files, err := Process("page.assets/photo.jpg", input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o := jpegOrientation(files["photo.jpg"]); o != 6 {
+		t.Errorf("expected the orientation to be kept, got %d", o)
+	}
+	variant, ok := files["photo-480w.jpg"]
+	if !ok {
+		t.Fatal("expected a 480w variant")
+	}
+	config, err = jpeg.DecodeConfig(bytes.NewReader(variant))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Width != 480 || config.Height != 960 {
+		t.Errorf("expected an upright 480x960 variant, got %dx%d", config.Width, config.Height)
+	}
+	if o := jpegOrientation(variant); o != 1 {
+		t.Errorf("expected the variant to be oriented, got orientation %d", o)
+	}
+}
+
+func TestOrient(t *testing.T) {
+	// a b c
+	// d e f
+	src := image.NewNRGBA(image.Rect(0, 0, 3, 2))
+	for i, c := range "abcdef" {
+		src.SetNRGBA(i%3, i/3, color.NRGBA{R: uint8(c), A: 0xff})
+	}
+	for o, expected := range map[int][]string{
+		1: {"abc", "def"},
+		2: {"cba", "fed"},
+		3: {"fed", "cba"},
+		4: {"def", "abc"},
+		5: {"ad", "be", "cf"},
+		6: {"da", "eb", "fc"},
+		7: {"fc", "eb", "da"},
+		8: {"cf", "be", "ad"},
+	} {
+		dst := orient(src, o)
+		b := dst.Bounds()
+		var got []string
+		for y := b.Min.Y; y < b.Max.Y; y++ {
+			var row []byte
+			for x := b.Min.X; x < b.Max.X; x++ {
+				r, _, _, _ := dst.At(x, y).RGBA()
+				row = append(row, byte(r>>8))
+			}
+			got = append(got, string(row))
+		}
+		if len(got) != len(expected) {
+			t.Errorf("%d: expected %v, got %v", o, expected, got)
+			continue
+		}
+		for i := range got {
+			if got[i] != expected[i] {
+				t.Errorf("%d: expected %v, got %v", o, expected, got)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package images
+
+import (
+	"fmt"
+	_ "image/gif" // Register GIF for image.DecodeConfig
+	"io/fs"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+	_ "golang.org/x/image/webp" // Register WebP for image.DecodeConfig
+)
+
+// DirKey is the parser context key of the directory of the page, used to
+// resolve relative images.
+var DirKey = parser.NewContextKey()
+
+// BaseKey is the parser context key of the URL path the relative images are
+// served from, when it is not the path of the page (e.g. a translation sharing
+// the images of the original page). The images are then linked absolutely.
+var BaseKey = parser.NewContextKey()
+
+// Widths are the widths of the resized variants of an image. Only the widths
+// smaller than the image are generated.
+var Widths = []int{480, 960, 1440}
+
+// Sizes is the "sizes" attribute of the images with variants. It follows the
+// width of the markdown content.
+const Sizes = "(min-width: 960px) 65vw, (min-width: 640px) 80vw, 90vw"
+
+// NewResponsive adds the dimensions, the lazy loading and the resized variants
+// of the images stored in fsys to the image html render.
+func NewResponsive(fsys fs.FS, variants *Variants) goldmark.Option {
+	return goldmark.WithParserOptions(
+		parser.WithASTTransformers(
+			util.Prioritized(&Transformer{FS: fsys, Variants: variants}, 0),
+		),
+	)
+}
+
+// Variants records the widths of the variants of the processed images. It is
+// safe for concurrent use.
+type Variants struct {
+	mu     sync.RWMutex
+	widths map[string][]int
+}
+
+// NewVariants returns an empty record.
+func NewVariants() *Variants {
+	return &Variants{widths: make(map[string][]int)}
+}
+
+// Add records the variants of the image at name among the files returned by
+// Process.
+func (v *Variants) Add(name string, files map[string][]byte) {
+	var widths []int
+	for _, w := range Widths {
+		if _, ok := files[Variant(path.Base(name), w)]; ok {
+			widths = append(widths, w)
+		}
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.widths[path.Clean(name)] = widths
+}
+
+// Get returns the widths of the variants of the image at name.
+func (v *Variants) Get(name string) []int {
+	if v == nil {
+		return nil
+	}
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return v.widths[path.Clean(name)]
+}
+
+// Transformer sets the attributes of the images from the files they link.
+//
+// The images are resolved from the directory stored at DirKey in the parser
+// context, and linked from BaseKey if set. Without DirKey, only the lazy loading
+// is set. The srcset only lists the
+// variants recorded in Variants, so the images must be processed first.
+type Transformer struct {
+	FS       fs.FS
+	Variants *Variants
+}
+
+// Transform implements parser.ASTTransformer interface.
+func (t *Transformer) Transform(doc *ast.Document, _ text.Reader, pc parser.Context) {
+	dir, _ := pc.Get(DirKey).(string)
+	base, _ := pc.Get(BaseKey).(string)
+	_ = ast.Walk(doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		n, ok := node.(*ast.Image)
+		if !ok {
+			return ast.WalkContinue, nil
+		}
+		n.SetAttribute([]byte("loading"), []byte("lazy"))
+		n.SetAttribute([]byte("decoding"), []byte("async"))
+		if dir == "" || t.FS == nil {
+			return ast.WalkContinue, nil
+		}
+
+		link := string(n.Destination)
+		if !isLocal(link) {
+			return ast.WalkContinue, nil
+		}
+		src := link
+		if base != "" {
+			src = path.Join(base, link)
+			n.Destination = []byte(src)
+		}
+		name := path.Join(dir, link)
+		b, err := fs.ReadFile(t.FS, name)
+		if err != nil {
+			return ast.WalkContinue, nil
+		}
+		config, _, err := decodeConfig(b)
+		if err != nil {
+			return ast.WalkContinue, nil
+		}
+		n.SetAttribute([]byte("width"), []byte(strconv.Itoa(config.Width)))
+		n.SetAttribute([]byte("height"), []byte(strconv.Itoa(config.Height)))
+
+		widths := t.Variants.Get(name)
+		if len(widths) == 0 {
+			return ast.WalkContinue, nil
+		}
+		srcset := make([]string, 0, len(widths)+1)
+		for _, w := range widths {
+			srcset = append(srcset, fmt.Sprintf("%s %dw", Variant(src, w), w))
+		}
+		srcset = append(srcset, fmt.Sprintf("%s %dw", src, config.Width))
+		n.SetAttribute([]byte("srcset"), []byte(strings.Join(srcset, ", ")))
+		n.SetAttribute([]byte("sizes"), []byte(Sizes))
+		return ast.WalkContinue, nil
+	})
+}
+
+// isLocal returns true if link is a relative link to a file.
+func isLocal(link string) bool {
+	return link != "" &&
+		!path.IsAbs(link) &&
+		!strings.Contains(link, ":") &&
+		!strings.Contains(link, "{{")
+}
+
+// IsImage returns true if the file at name may be processed by Process.
+func IsImage(name string) bool {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".png", ".jpg", ".jpeg":
+		return true
+	}
+	return false
+}
+
+// variantWidths returns the candidate widths of the variants of an image, from
+// its format (as returned by image.DecodeConfig) and its width.
+func variantWidths(format string, width int) []int {
+	if format != "png" && format != "jpeg" {
+		return nil
+	}
+	var widths []int
+	for _, w := range Widths {
+		if w < width {
+			widths = append(widths, w)
+		}
+	}
+	return widths
+}
+
+// Variant returns the name of the variant of the image at name, of the given
+// width.
+//
+// Example: page.assets/image.png -> page.assets/image-480w.png
+func Variant(name string, width int) string {
+	ext := path.Ext(name)
+	return fmt.Sprintf("%s-%dw%s", strings.TrimSuffix(name, ext), width, ext)
+}
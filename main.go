@@ -1,10 +1,17 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"io"
+	"io/fs"
 	"net/http"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/Darkness4/blog/api/search"
 	"github.com/Darkness4/blog/db"
@@ -13,7 +20,9 @@ import (
 	"github.com/Darkness4/blog/web"
 	"github.com/Darkness4/blog/web/gen/index"
 	"github.com/Darkness4/blog/web/middleware"
+	"github.com/Darkness4/blog/web/preview"
 	"github.com/go-chi/chi/v5"
+	"github.com/gorilla/feeds"
 	"github.com/joho/godotenv"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/hlog"
@@ -35,8 +44,26 @@ var (
 	meilisearchKey   string
 	meilisearchID    string
 	meilisearchClean bool
+
+	previewSecret string
+	previewPath   string
+	previewTTL    time.Duration
+
+	contentDir          string
+	contentPollInterval time.Duration
+
+	exportDir string
 )
 
+// serverFlags are the flags required to run the server, but not by the other
+// commands.
+var serverFlags = []string{
+	"db.dsn",
+	"meilisearch.url",
+	"meilisearch.master-key",
+	"meilisearch.index-uid",
+}
+
 var app = &cli.Command{
 	Name:    "blog",
 	Version: version,
@@ -62,28 +89,24 @@ var app = &cli.Command{
 			Usage:       "The DSN for the database",
 			Destination: &dbDSN,
 			Sources:     cli.EnvVars("DB_DSN"),
-			Required:    true,
 		},
 		&cli.StringFlag{
 			Name:        "meilisearch.url",
 			Usage:       "The URL for the Meilisearch instance.",
 			Destination: &meilisearchURL,
 			Sources:     cli.EnvVars("MEILISEARCH_URL"),
-			Required:    true,
 		},
 		&cli.StringFlag{
 			Name:        "meilisearch.master-key",
 			Usage:       "The API key for the Meilisearch instance.",
 			Destination: &meilisearchKey,
 			Sources:     cli.EnvVars("MEILISEARCH_MASTER_KEY"),
-			Required:    true,
 		},
 		&cli.StringFlag{
 			Name:        "meilisearch.index-uid",
 			Usage:       "The Index UID for the Meilisearch instance.",
 			Destination: &meilisearchID,
 			Sources:     cli.EnvVars("MEILISEARCH_INDEX_UID"),
-			Required:    true,
 		},
 		&cli.BoolFlag{
 			Name:        "meilisearch.clean",
@@ -91,6 +114,25 @@ var app = &cli.Command{
 			Destination: &meilisearchClean,
 			Sources:     cli.EnvVars("MEILISEARCH_CLEAN"),
 		},
+		&cli.StringFlag{
+			Name:        "preview.secret",
+			Usage:       "The secret used to sign preview links of drafts and scheduled pages. Previews are disabled if empty.",
+			Destination: &previewSecret,
+			Sources:     cli.EnvVars("PREVIEW_SECRET"),
+		},
+		&cli.StringFlag{
+			Name:        "content.dir",
+			Usage:       "Serve the content of a directory laid out as the web directory after go generate (gen, components, static, base.html...), instead of the embedded content",
+			Destination: &contentDir,
+			Sources:     cli.EnvVars("CONTENT_DIR"),
+		},
+		&cli.DurationFlag{
+			Name:        "content.poll-interval",
+			Usage:       "The interval between the checks for changes of content.dir. The content is always reloaded on SIGHUP. 0 disables the checks",
+			Value:       10 * time.Second,
+			Destination: &contentPollInterval,
+			Sources:     cli.EnvVars("CONTENT_POLL_INTERVAL"),
+		},
 		&cli.StringFlag{
 			Name:        "csp",
 			Usage:       "The Content Security Policy",
@@ -102,7 +144,7 @@ form-action 'self';
 frame-ancestors 'none';
 script-src 'self' 'unsafe-inline' https://giscus.app/ https://unpkg.com/ https://cloud.umami.is/;
 style-src 'self' 'unsafe-inline' https://giscus.app/ https://unpkg.com/ https://fonts.googleapis.com/;
-connect-src 'self' https://cloud.umami.is/ https://unpkg.com {{ .MeilisearchURL }};
+connect-src 'self' https://cloud.umami.is/ {{ .MeilisearchURL }};
 media-src 'self' https://www.youtube.com/ https://www.youtube-nocookie.com/;
 frame-src https://giscus.app/ https://www.youtube.com/ https://www.youtube-nocookie.com/;
 font-src 'self' data: https://fonts.gstatic.com/;
@@ -111,9 +153,65 @@ worker-src blob:;
 object-src 'none';`,
 		},
 	},
-	Action: func(ctx context.Context, _ *cli.Command) error {
+	Commands: []*cli.Command{
+		{
+			Name:  "dev",
+			Usage: "Serve the pages from the sources, and rebuild and reload them on change. Run it at the root of the repository.",
+			Action: func(ctx context.Context, _ *cli.Command) error {
+				return runDev(ctx)
+			},
+		},
+		{
+			Name:  "export",
+			Usage: "Write the pages, the static files, the feeds and the sitemap to a directory served by a static host.",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:        "out",
+					Usage:       "The output directory",
+					Destination: &exportDir,
+					Required:    true,
+				},
+			},
+			Action: func(_ context.Context, _ *cli.Command) error {
+				return runExport(exportDir)
+			},
+		},
+		{
+			Name:  "preview",
+			Usage: "Print a preview link of a draft or scheduled page.",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:        "path",
+					Usage:       "The path of the page (e.g. /blog/2024-01-11-cgo-guide)",
+					Destination: &previewPath,
+					Required:    true,
+				},
+				&cli.DurationFlag{
+					Name:        "ttl",
+					Usage:       "The validity of the link",
+					Value:       7 * 24 * time.Hour,
+					Destination: &previewTTL,
+				},
+			},
+			Action: func(_ context.Context, _ *cli.Command) error {
+				if previewSecret == "" {
+					return fmt.Errorf("preview.secret is not set")
+				}
+				token := preview.Sign([]byte(previewSecret), previewPath, time.Now().Add(previewTTL))
+				fmt.Printf("%s%s?%s=%s\n", publicURL, previewPath, preview.QueryParam, token)
+				return nil
+			},
+		},
+	},
+	Action: func(ctx context.Context, cmd *cli.Command) error {
 		log.Level(zerolog.DebugLevel)
 
+		for _, name := range serverFlags {
+			if !cmd.IsSet(name) {
+				return fmt.Errorf("required flag %q not set", name)
+			}
+		}
+
 		// DB connection
 		pool, err := pgxpool.New(ctx, dbDSN)
 		if err != nil {
@@ -136,13 +234,39 @@ object-src 'none';`,
 				return fmt.Errorf("failed to clear index: %w", err)
 			}
 		}
-		if err = meili.BuildIndex(ctx, index.Pages); err != nil {
+		now := time.Now()
+		if err = meili.BuildIndex(ctx, index.ListedAt(now)); err != nil {
 			return fmt.Errorf("failed to build index: %w", err)
 		}
+		if err = meili.DeleteRecords(ctx, index.UnlistedAt(now)); err != nil {
+			return fmt.Errorf("failed to delete unlisted pages from index: %w", err)
+		}
+		go reindexOnPublication(ctx, meili)
 
 		// Set up DB queries
 		q := db.New(pool)
 
+		// Content
+		content := web.NewContent(web.Embedded())
+		if contentDir != "" {
+			fsys, err := web.LoadDir(contentDir)
+			if err != nil {
+				return err
+			}
+			content.Replace(fsys)
+			go content.WatchDir(ctx, contentDir, contentPollInterval)
+			log.Info().Str("dir", contentDir).Msg("serving content directory")
+		}
+
+		// Pages
+		pages := web.NewPages(content, publicURL, []byte(previewSecret))
+		start := time.Now()
+		n, err := pages.Prerender()
+		if err != nil {
+			return fmt.Errorf("failed to render pages: %w", err)
+		}
+		log.Info().Int("pages", n).Dur("duration", time.Since(start)).Msg("pages rendered")
+
 		// Router
 		r := chi.NewRouter()
 		r.Use(hlog.NewHandler(log.Logger))
@@ -155,48 +279,156 @@ object-src 'none';`,
 		})
 
 		// Pages rendering
-		r.Get("/rss", func(w http.ResponseWriter, _ *http.Request) {
-			w.Header().Set("Content-Type", "application/rss+xml")
-			if err := index.Feed.WriteRss(w); err != nil {
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
-		})
-		r.Get("/atom", func(w http.ResponseWriter, _ *http.Request) {
-			w.Header().Set("Content-Type", "application/atom+xml")
-			if err := index.Feed.WriteAtom(w); err != nil {
-				http.Error(w, err.Error(), http.StatusInternalServerError)
+		for _, lang := range index.Languages {
+			prefix := index.LanguagePrefix(lang)
+			for _, format := range feedFormats {
+				r.Get(prefix+"/"+format.name, feedHandler(format, func(_ *http.Request, limit int) (*feeds.Feed, bool) {
+					return newFeed(content, lang, limit), true
+				}))
+				r.Get(prefix+"/tags/{tag}/"+format.name, feedHandler(format, func(r *http.Request, limit int) (*feeds.Feed, bool) {
+					return newTagFeed(content, lang, chi.URLParam(r, "tag"), limit)
+				}))
 			}
-		})
-		r.Get("/json", func(w http.ResponseWriter, _ *http.Request) {
-			w.Header().Set("Content-Type", "application/json")
-			if err := index.Feed.WriteJSON(w); err != nil {
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
-		})
+		}
 		r.Get("/sitemap.xml", func(w http.ResponseWriter, _ *http.Request) {
-			b, err := index.ToSiteMap(index.Pages)
-			if err != nil {
+			w.Header().Set("Content-Type", "application/xml")
+			if err := writeSitemap(w); err != nil {
 				http.Error(w, err.Error(), http.StatusInternalServerError)
 			}
-			w.Header().Set("Content-Type", "application/xml")
-			header := `<?xml version="1.0" encoding="UTF-8"?>`
-			fmt.Fprintf(w, "%s\n%s", header, b)
 		})
 		r.Get("/robots.txt", func(w http.ResponseWriter, _ *http.Request) {
-			fmt.Fprintf(w, `User-agent: *
+			_ = writeRobots(w)
+		})
+		r.Post(web.ViewsPath+"/*", web.ViewsFunc(q, pool))
+		r.Get("/*", pages.ServeHTTP)
+		r.Handle("/static/*", web.StaticFunc(content))
+
+		log.Info().Str("listenAddress", listenAddress).Msg("listening")
+		return http.ListenAndServe(listenAddress, r)
+	},
+}
+
+// writeSitemap writes the sitemap of the pages listed now.
+func writeSitemap(w io.Writer) error {
+	b, err := index.ToSiteMap(index.ListedAt(time.Now()))
+	if err != nil {
+		return err
+	}
+	header := `<?xml version="1.0" encoding="UTF-8"?>`
+	_, err = fmt.Fprintf(w, "%s\n%s", header, b)
+	return err
+}
+
+// writeRobots writes the robots.txt.
+func writeRobots(w io.Writer) error {
+	_, err := fmt.Fprintf(w, `User-agent: *
 Disallow:
 
 Sitemap: %s/sitemap.xml
 Sitemap: %s/rss
 Sitemap: %s/atom
 `, publicURL, publicURL, publicURL)
-		})
-		r.Get("/*", web.RenderFunc(q, pool, publicURL))
-		r.Handle("/static/*", web.StaticFunc())
+	return err
+}
 
-		log.Info().Str("listenAddress", listenAddress).Msg("listening")
-		return http.ListenAndServe(listenAddress, r)
-	},
+// feedFormat is a format the feeds are served in.
+type feedFormat struct {
+	name        string
+	contentType string
+	write       func(*feeds.Feed, io.Writer) error
+}
+
+// feedFormats are the formats of the feeds.
+var feedFormats = []feedFormat{
+	{name: "rss", contentType: "application/rss+xml", write: index.WriteRSS},
+	{name: "atom", contentType: "application/atom+xml", write: index.WriteAtom},
+	{name: "json", contentType: "application/json", write: index.WriteJSONFeed},
+}
+
+// feedHandler serves the feed returned by newFeed in the given format, or a 404
+// if there is none.
+//
+// The "limit" query parameter is the maximum number of items of the feed, all
+// of them if not set. The feed can be requested conditionally, with its ETag
+// or its last update.
+func feedHandler(
+	format feedFormat,
+	newFeed func(r *http.Request, limit int) (*feeds.Feed, bool),
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		limit := 0
+		if s := r.URL.Query().Get("limit"); s != "" {
+			var err error
+			if limit, err = strconv.Atoi(s); err != nil || limit <= 0 {
+				http.Error(w, "invalid limit", http.StatusBadRequest)
+				return
+			}
+		}
+		feed, ok := newFeed(r, limit)
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		var buf bytes.Buffer
+		if err := format.write(feed, &buf); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		sum := sha256.Sum256(buf.Bytes())
+		w.Header().Set("Content-Type", format.contentType)
+		w.Header().Set("ETag", `"`+hex.EncodeToString(sum[:16])+`"`)
+		http.ServeContent(w, r, "", feed.Updated, bytes.NewReader(buf.Bytes()))
+	}
+}
+
+// newFeed returns the feed of the pages in lang listed now, the newest first,
+// with the articles of the content fsys. If limit is not 0, only the limit
+// newest pages are in the feed.
+func newFeed(fsys fs.FS, lang string, limit int) *feeds.Feed {
+	entries := index.InLanguage(index.ListedAt(time.Now()), lang)
+	return index.NewFeed(lang, limitEntries(entries, limit), web.FeedContentFunc(fsys))
+}
+
+// newTagFeed returns the feed of the pages in lang listed now with a tag, by its
+// slug. See newFeed.
+func newTagFeed(fsys fs.FS, lang string, slug string, limit int) (*feeds.Feed, bool) {
+	entries := index.InLanguage(index.ListedAt(time.Now()), lang)
+	tag, ok := index.LookupTag(entries, slug)
+	if !ok {
+		return nil, false
+	}
+	entries = limitEntries(index.WithTag(entries, tag.Slug), limit)
+	feed := index.NewFeed(lang, entries, web.FeedContentFunc(fsys))
+	feed.Title += " - " + tag.Name
+	feed.Link.Href += "/tags/" + tag.Slug
+	return feed, true
+}
+
+// limitEntries returns the limit first entries, or all of them if limit is 0.
+func limitEntries(entries []index.Index, limit int) []index.Index {
+	if limit > 0 && limit < len(entries) {
+		return entries[:limit]
+	}
+	return entries
+}
+
+// reindexOnPublication adds the scheduled pages to the search index once they
+// are published.
+func reindexOnPublication(ctx context.Context, meili *meilisearch.Client) {
+	for {
+		next, ok := index.NextPublication(time.Now())
+		if !ok {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Until(next)):
+		}
+		if err := meili.BuildIndex(ctx, index.ListedAt(time.Now())); err != nil {
+			log.Err(err).Msg("failed to update index")
+		}
+	}
 }
 
 func main() {
@@ -0,0 +1,194 @@
+package markdown
+
+import (
+	"bufio"
+	"bytes"
+	"path"
+
+	highlighting "github.com/yuin/goldmark-highlighting/v2"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// CodeBlockRenderer renders the fenced code blocks with goldmark-highlighting.
+//
+// On top of the attributes of goldmark-highlighting (hl_lines, linenostart,
+// ...), it supports:
+//
+//   - title="main.go": a header with the title.
+//   - diff=true: the lines starting with "+" and "-" are styled as added and
+//     removed lines.
+//   - download="main.go": a button to download the content of the block as a
+//     file.
+//
+// Example:
+//
+//	```go {title="main.go" hl_lines=[2,"4-5"] download="main.go"}
+type CodeBlockRenderer struct {
+	highlight renderer.NodeRendererFunc
+}
+
+// NewCodeBlockRenderer returns a new CodeBlockRenderer with the given options.
+//
+// The wrapper renderer must not be set: it is replaced by the one of the
+// CodeBlockRenderer.
+func NewCodeBlockRenderer(opts ...highlighting.Option) *CodeBlockRenderer {
+	opts = append(opts, highlighting.WithWrapperRenderer(codeBlockWrapper))
+	r := &CodeBlockRenderer{}
+	highlighting.NewHTMLRenderer(opts...).RegisterFuncs(registererFunc(
+		func(kind ast.NodeKind, f renderer.NodeRendererFunc) {
+			if kind == ast.KindFencedCodeBlock {
+				r.highlight = f
+			}
+		},
+	))
+	return r
+}
+
+type registererFunc func(kind ast.NodeKind, f renderer.NodeRendererFunc)
+
+func (f registererFunc) Register(kind ast.NodeKind, fn renderer.NodeRendererFunc) {
+	f(kind, fn)
+}
+
+// RegisterFuncs implements NodeRenderer.RegisterFuncs interface.
+func (r *CodeBlockRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(ast.KindFencedCodeBlock, r.renderFencedCodeBlock)
+}
+
+func (r *CodeBlockRenderer) renderFencedCodeBlock(
+	w util.BufWriter,
+	source []byte,
+	node ast.Node,
+	entering bool,
+) (ast.WalkStatus, error) {
+	n := node.(*ast.FencedCodeBlock)
+	if !entering || !isDiff(n, source) {
+		return r.highlight(w, source, node, entering)
+	}
+
+	var buf bytes.Buffer
+	bw := bufio.NewWriter(&buf)
+	status, err := r.highlight(bw, source, node, entering)
+	if err != nil {
+		return status, err
+	}
+	if err := bw.Flush(); err != nil {
+		return status, err
+	}
+	_, err = w.Write(markDiffLines(buf.Bytes(), n, source))
+	return status, err
+}
+
+// lineTag is the opening tag of a line rendered by chroma with classes.
+var lineTag = []byte(`<span class="line`)
+
+// markDiffLines adds the diff-add and diff-del classes to the lines of the
+// rendered code block starting with "+" and "-".
+func markDiffLines(rendered []byte, n *ast.FencedCodeBlock, source []byte) []byte {
+	out := make([]byte, 0, len(rendered)+64)
+	lines := n.Lines()
+	for i := 0; i < lines.Len(); i++ {
+		idx := bytes.Index(rendered, lineTag)
+		if idx < 0 {
+			break
+		}
+		idx += len(lineTag)
+		out = append(out, rendered[:idx]...)
+		rendered = rendered[idx:]
+		segment := lines.At(i)
+		line := segment.Value(source)
+		switch {
+		case bytes.HasPrefix(line, []byte("+")):
+			out = append(out, " diff-add"...)
+		case bytes.HasPrefix(line, []byte("-")):
+			out = append(out, " diff-del"...)
+		}
+	}
+	return append(out, rendered...)
+}
+
+// isDiff reports whether the code block has the diff=true attribute.
+func isDiff(n *ast.FencedCodeBlock, source []byte) bool {
+	if n.Info == nil {
+		return false
+	}
+	info := n.Info.Segment.Value(source)
+	idx := bytes.IndexByte(info, '{')
+	if idx <= 0 {
+		return false
+	}
+	attrs, ok := parser.ParseAttributes(text.NewReader(info[idx:]))
+	if !ok {
+		return false
+	}
+	v, ok := attrs.Find([]byte("diff"))
+	return ok && v == true
+}
+
+// stringAttribute returns the value of a string attribute of a code block.
+func stringAttribute(attrs highlighting.ImmutableAttributes, name string) string {
+	if attrs == nil {
+		return ""
+	}
+	attr, _ := attrs.GetString(name)
+	if v, ok := attr.([]uint8); ok {
+		return string(v)
+	}
+	return ""
+}
+
+func codeBlockWrapper(w util.BufWriter, ctx highlighting.CodeBlockContext, entering bool) {
+	attrs := ctx.Attributes()
+	title := stringAttribute(attrs, "title")
+	// Only a file name is accepted, the browser decides where to save it.
+	download := path.Base(stringAttribute(attrs, "download"))
+	if download == "." || download == "/" {
+		download = ""
+	}
+
+	if entering {
+		w.WriteString("<div class=\"chroma code-container\">") // Open code-container
+		if title != "" {
+			w.WriteString("<div class=\"code-header\">")
+			w.WriteString(title)
+			w.WriteString("</div>")
+		}
+		w.WriteString("<div class=\"code-content\">") // Open code-block
+		w.WriteString("<div class=\"code-btn-group\">")
+		// Download code button
+		if download != "" {
+			w.WriteString(`<button type="button" aria-label="Download code" title="Download `)
+			w.Write(util.EscapeHTML([]byte(download)))
+			w.WriteString(`" class="download-btn" data-filename="`)
+			w.Write(util.EscapeHTML([]byte(download)))
+			w.WriteString(`"
+			_="on click call downloadCode(closest parent .code-content, @data-filename)">`)
+			w.WriteString(
+				"<svg viewBox=\"0 0 24 24\" width=\"16\" height=\"16\" aria-hidden=\"true\"><path fill=\"currentColor\" d=\"M5,20H19V18H5M19,9H15V3H9V9H5L12,16L19,9Z\"></path></svg>",
+			)
+			w.WriteString("</button>")
+		}
+		// Copy code button
+		w.WriteString(
+			`<button type="button" aria-label="Copy code to clipboard" title="Copy" class="copy-btn"
+			_="on click call copyCode(closest parent .code-content)">`,
+		)
+		w.WriteString("<span aria-hidden=\"true\" class=\"copy-btn-icons\">")
+		w.WriteString(
+			"<svg viewBox=\"0 0 24 24\" class=\"copy-btn-icon\"><path fill=\"currentColor\" d=\"M19,21H8V7H19M19,5H8A2,2 0 0,0 6,7V21A2,2 0 0,0 8,23H19A2,2 0 0,0 21,21V7A2,2 0 0,0 19,5M16,1H4A2,2 0 0,0 2,3V17H4V3H16V1Z\"></path></svg>",
+		)
+		w.WriteString(
+			"<svg viewBox=\"0 0 24 24\" class=\"copy-btn-success-icon\"><path fill=\"currentColor\" d=\"M21,7L9,19L3.5,13.5L4.91,12.09L9,16.17L19.59,5.59L21,7Z\"></path></svg>",
+		)
+		w.WriteString("</span>")
+		w.WriteString("</button>")
+		w.WriteString("</div>")
+	} else {
+		w.WriteString("</div>") // Close code-block
+		w.WriteString(`</div>`) // Close code-container
+	}
+}
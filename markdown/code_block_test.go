@@ -0,0 +1,64 @@
+package markdown_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/Darkness4/blog/markdown"
+	chromahtml "github.com/alecthomas/chroma/v2/formatters/html"
+	"github.com/yuin/goldmark"
+	highlighting "github.com/yuin/goldmark-highlighting/v2"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/util"
+)
+
+func TestCodeBlockRenderer(t *testing.T) {
+	tests := []struct {
+		title    string
+		input    string
+		expected []string
+	}{
+		{
+			title:    "Highlighted lines",
+			input:    "```go {hl_lines=[2] linenostart=10}\na := 1\nb := 2\n```\n",
+			expected: []string{`<span class="line hl"><span class="ln">11</span>`},
+		},
+		{
+			title: "Diff",
+			input: "```go {diff=true}\n a := 1\n-b := 2\n+b := 3\n```\n",
+			expected: []string{
+				`<span class="line"><span class="ln">1</span>`,
+				`<span class="line diff-del"><span class="ln">2</span>`,
+				`<span class="line diff-add"><span class="ln">3</span>`,
+			},
+		},
+		{
+			title:    "Download",
+			input:    "```go {download=\"../cmd/main.go\"}\npackage main\n```\n",
+			expected: []string{`class="download-btn" data-filename="main.go"`},
+		},
+	}
+
+	md := goldmark.New(goldmark.WithRendererOptions(
+		renderer.WithNodeRenderers(util.Prioritized(markdown.NewCodeBlockRenderer(
+			highlighting.WithFormatOptions(
+				chromahtml.WithLineNumbers(true),
+				chromahtml.WithClasses(true),
+			),
+		), 1)),
+	))
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := md.Convert([]byte(tt.input), &buf); err != nil {
+				t.Fatal(err)
+			}
+			for _, e := range tt.expected {
+				if !strings.Contains(buf.String(), e) {
+					t.Errorf("expected %q in:\n%s", e, buf.String())
+				}
+			}
+		})
+	}
+}
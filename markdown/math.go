@@ -0,0 +1,253 @@
+package markdown
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+	"sync"
+
+	mathjax "github.com/litao91/goldmark-mathjax"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+	"oss.terrastruct.com/d2/d2renderers/d2latex"
+)
+
+// MathInline is an inline formula, rendered to SVG.
+type MathInline struct {
+	ast.BaseInline
+	TeX string
+	SVG string
+}
+
+// KindMathInline is the NodeKind of MathInline.
+var KindMathInline = ast.NewNodeKind("MathInline")
+
+// Kind implements Node.Kind.
+func (n *MathInline) Kind() ast.NodeKind {
+	return KindMathInline
+}
+
+// Dump implements Node.Dump.
+func (n *MathInline) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, map[string]string{"TeX": n.TeX}, nil)
+}
+
+// MathDisplay is a display formula, rendered to SVG.
+type MathDisplay struct {
+	ast.BaseBlock
+	TeX string
+	SVG string
+}
+
+// KindMathDisplay is the NodeKind of MathDisplay.
+var KindMathDisplay = ast.NewNodeKind("MathDisplay")
+
+// Kind implements Node.Kind.
+func (n *MathDisplay) Kind() ast.NodeKind {
+	return KindMathDisplay
+}
+
+// Dump implements Node.Dump.
+func (n *MathDisplay) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, map[string]string{"TeX": n.TeX}, nil)
+}
+
+// MathError is an invalid formula.
+type MathError struct {
+	Path string
+	Line int
+	TeX  string
+	Err  error
+}
+
+func (e *MathError) Error() string {
+	return fmt.Sprintf("%s:%d: math: %v: %s", e.Path, e.Line, e.Err, e.TeX)
+}
+
+func (e *MathError) Unwrap() error {
+	return e.Err
+}
+
+var mathErrorsKey = parser.NewContextKey()
+
+// MathErrors returns the invalid formulas found while parsing with pc. Their
+// Path is left empty.
+func MathErrors(pc parser.Context) []*MathError {
+	errs, _ := pc.Get(mathErrorsKey).([]*MathError)
+	return errs
+}
+
+// Math is a goldmark extension rendering the math delimited by $...$ and
+// $$...$$ to SVG at build time, so the pages show math without JavaScript.
+//
+// The invalid formulas are reported in MathErrors.
+var Math = &mathExtender{}
+
+type mathExtender struct{}
+
+// Extend implements goldmark.Extender interface.
+func (e *mathExtender) Extend(m goldmark.Markdown) {
+	mathjax.MathJax.Extend(m)
+	m.Parser().AddOptions(parser.WithASTTransformers(
+		util.Prioritized(&MathTransformer{}, 100),
+	))
+	m.Renderer().AddOptions(renderer.WithNodeRenderers(
+		util.Prioritized(&MathRenderer{}, 100),
+	))
+}
+
+// MathTransformer replaces the formulas parsed by goldmark-mathjax with their
+// SVG rendering.
+type MathTransformer struct{}
+
+// Transform implements parser.ASTTransformer interface.
+func (t *MathTransformer) Transform(doc *ast.Document, reader text.Reader, pc parser.Context) {
+	source := reader.Source()
+	var nodes []ast.Node
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch n.Kind() {
+		case mathjax.KindInlineMath, mathjax.KindMathBlock:
+			nodes = append(nodes, n)
+			return ast.WalkSkipChildren, nil
+		}
+		return ast.WalkContinue, nil
+	})
+
+	for _, n := range nodes {
+		var (
+			tex      string
+			start    int
+			display  bool
+			rendered ast.Node
+		)
+		if n.Kind() == mathjax.KindMathBlock {
+			var sb strings.Builder
+			lines := n.Lines()
+			for i := 0; i < lines.Len(); i++ {
+				segment := lines.At(i)
+				sb.Write(segment.Value(source))
+			}
+			if lines.Len() > 0 {
+				start = lines.At(0).Start
+			}
+			tex, display = strings.TrimSpace(sb.String()), true
+		} else {
+			var sb strings.Builder
+			for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+				if t, ok := c.(*ast.Text); ok {
+					if c == n.FirstChild() {
+						start = t.Segment.Start
+					}
+					sb.WriteString(strings.TrimSuffix(string(t.Segment.Value(source)), "\n"))
+					if c != n.LastChild() {
+						sb.WriteByte(' ')
+					}
+				}
+			}
+			tex = strings.TrimSpace(sb.String())
+		}
+
+		svg, err := renderTeX(tex, display)
+		if err != nil {
+			pc.Set(mathErrorsKey, append(MathErrors(pc), &MathError{
+				Line: bytes.Count(source[:start], []byte("\n")) + 1,
+				TeX:  tex,
+				Err:  err,
+			}))
+		}
+		if display {
+			rendered = &MathDisplay{TeX: tex, SVG: svg}
+		} else {
+			rendered = &MathInline{TeX: tex, SVG: svg}
+		}
+		n.Parent().ReplaceChild(n.Parent(), n, rendered)
+	}
+}
+
+// mathCache caches the renderings of the formulas, as MathJax is slow to
+// start. It is shared by the pages.
+var mathCache sync.Map
+
+type mathKey struct {
+	tex     string
+	display bool
+}
+
+type mathResult struct {
+	svg string
+	err error
+}
+
+// mathError matches the errors MathJax renders in place of an invalid formula.
+var mathError = regexp.MustCompile(`data-mjx-error="([^"]*)"`)
+
+// renderTeX renders a formula to SVG with MathJax.
+func renderTeX(tex string, display bool) (string, error) {
+	key := mathKey{tex: tex, display: display}
+	if r, ok := mathCache.Load(key); ok {
+		return r.(mathResult).svg, r.(mathResult).err
+	}
+
+	input := tex
+	if !display {
+		// MathJax renders in display mode.
+		input = `\textstyle ` + tex
+	}
+	svg, err := d2latex.Render(input)
+	if err == nil {
+		if m := mathError.FindStringSubmatch(svg); m != nil {
+			err = errors.New(html.UnescapeString(m[1]))
+		}
+	}
+	// The TeX is the accessible name of the formula.
+	svg = strings.Replace(svg, "<svg ", `<svg aria-label="`+html.EscapeString(tex)+`" `, 1)
+	mathCache.Store(key, mathResult{svg: svg, err: err})
+	return svg, err
+}
+
+// MathRenderer renders the formulas replaced by MathTransformer.
+type MathRenderer struct{}
+
+// RegisterFuncs implements NodeRenderer.RegisterFuncs interface.
+func (r *MathRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(KindMathInline, r.renderMathInline)
+	reg.Register(KindMathDisplay, r.renderMathDisplay)
+}
+
+func (r *MathRenderer) renderMathInline(
+	w util.BufWriter,
+	_ []byte,
+	node ast.Node,
+	entering bool,
+) (ast.WalkStatus, error) {
+	if entering {
+		_, _ = w.WriteString(`<span class="math inline">`)
+		_, _ = w.WriteString(node.(*MathInline).SVG)
+		_, _ = w.WriteString(`</span>`)
+	}
+	return ast.WalkContinue, nil
+}
+
+func (r *MathRenderer) renderMathDisplay(
+	w util.BufWriter,
+	_ []byte,
+	node ast.Node,
+	entering bool,
+) (ast.WalkStatus, error) {
+	if entering {
+		_, _ = w.WriteString(`<div class="math display">`)
+		_, _ = w.WriteString(node.(*MathDisplay).SVG)
+		_, _ = w.WriteString("</div>\n")
+	}
+	return ast.WalkContinue, nil
+}
@@ -0,0 +1,38 @@
+package markdown_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/Darkness4/blog/markdown"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/parser"
+)
+
+func TestMath(t *testing.T) {
+	md := goldmark.New(goldmark.WithExtensions(markdown.Math))
+	input := "Inline $x^2$ math.\n\n$$\n\\frac{a}{b}\n$$\n\nInvalid $\\frac{a}{b$.\n"
+
+	var out bytes.Buffer
+	pc := parser.NewContext()
+	if err := md.Convert([]byte(input), &out, parser.WithContext(pc)); err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		`<span class="math inline"><svg aria-label="x^2"`,
+		`<div class="math display"><svg aria-label="\frac{a}{b}"`,
+	} {
+		if !strings.Contains(out.String(), expected) {
+			t.Errorf("expected %q in:\n%s", expected, out.String())
+		}
+	}
+
+	errs := markdown.MathErrors(pc)
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error, got %v", errs)
+	}
+	if errs[0].Line != 7 {
+		t.Errorf("expected error at line 7, got %d: %v", errs[0].Line, errs[0])
+	}
+}
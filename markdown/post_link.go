@@ -0,0 +1,63 @@
+package markdown
+
+import (
+	"bytes"
+
+	"github.com/Darkness4/blog/utils/blog"
+	"github.com/Darkness4/blog/utils/i18n"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+)
+
+// PostScheme is the scheme of the shorthand links to a blog post, e.g.
+// [x](post:2024-01-11-cgo-guide#section).
+const PostScheme = "post:"
+
+// LanguageKey is the parser context key of the language of the page. Without
+// it, the page is in the default language.
+var LanguageKey = parser.NewContextKey()
+
+// PostLinkTransformer expands the shorthand links to a blog post into their
+// canonical URL, in the language of the page stored at LanguageKey in the
+// parser context.
+type PostLinkTransformer struct {
+	// Translated reports whether a post is translated in lang. If nil, the
+	// links always target the default language.
+	Translated func(post string, lang string) bool
+}
+
+// Transform implements parser.ASTTransformer interface.
+func (t *PostLinkTransformer) Transform(doc *ast.Document, _ text.Reader, pc parser.Context) {
+	lang, ok := pc.Get(LanguageKey).(string)
+	if !ok {
+		lang = i18n.Default
+	}
+	_ = ast.Walk(doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		if n, ok := node.(*ast.Link); ok {
+			n.Destination = ExpandPostLink(n.Destination, lang, t.Translated)
+		}
+		return ast.WalkContinue, nil
+	})
+}
+
+// ExpandPostLink expands a shorthand link to a blog post into its canonical
+// URL in lang if translated reports the post is translated in lang, or in the
+// default language otherwise. Other links are returned as is.
+func ExpandPostLink(dest []byte, lang string, translated func(post string, lang string) bool) []byte {
+	name, ok := bytes.CutPrefix(dest, []byte(PostScheme))
+	if !ok {
+		return dest
+	}
+	post := name
+	if i := bytes.IndexAny(post, "?#"); i >= 0 {
+		post = post[:i]
+	}
+	if lang == i18n.Default || translated == nil || !translated(string(post), lang) {
+		lang = i18n.Default
+	}
+	return append([]byte(blog.LanguagePrefix(lang)+"/blog/"), name...)
+}
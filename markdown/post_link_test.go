@@ -0,0 +1,117 @@
+package markdown_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/Darkness4/blog/markdown"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/util"
+)
+
+// translatedInFrench reports the posts translated in French.
+func translatedInFrench(post string, lang string) bool {
+	return lang == "fr" && post == "2024-01-11-cgo-guide"
+}
+
+func TestExpandPostLink(t *testing.T) {
+	tests := []struct {
+		title      string
+		dest       string
+		lang       string
+		translated func(string, string) bool
+		expected   string
+	}{
+		{
+			title:      "Default language",
+			dest:       "post:2024-01-11-cgo-guide",
+			lang:       "en",
+			translated: translatedInFrench,
+			expected:   "/blog/2024-01-11-cgo-guide",
+		},
+		{
+			title:      "Translated",
+			dest:       "post:2024-01-11-cgo-guide",
+			lang:       "fr",
+			translated: translatedInFrench,
+			expected:   "/fr/blog/2024-01-11-cgo-guide",
+		},
+		{
+			title:      "Translated with fragment",
+			dest:       "post:2024-01-11-cgo-guide#section",
+			lang:       "fr",
+			translated: translatedInFrench,
+			expected:   "/fr/blog/2024-01-11-cgo-guide#section",
+		},
+		{
+			title:      "Translated with query",
+			dest:       "post:2024-01-11-cgo-guide?a=b",
+			lang:       "fr",
+			translated: translatedInFrench,
+			expected:   "/fr/blog/2024-01-11-cgo-guide?a=b",
+		},
+		{
+			title:      "Not translated",
+			dest:       "post:2023-09-10-developing-blog#section",
+			lang:       "fr",
+			translated: translatedInFrench,
+			expected:   "/blog/2023-09-10-developing-blog#section",
+		},
+		{
+			title:    "Without translations",
+			dest:     "post:2024-01-11-cgo-guide",
+			lang:     "fr",
+			expected: "/blog/2024-01-11-cgo-guide",
+		},
+		{
+			title:      "Other link",
+			dest:       "https://example.com/post:a",
+			lang:       "fr",
+			translated: translatedInFrench,
+			expected:   "https://example.com/post:a",
+		},
+		{
+			title:      "Relative link",
+			dest:       "page.assets/a.png",
+			lang:       "fr",
+			translated: translatedInFrench,
+			expected:   "page.assets/a.png",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			got := markdown.ExpandPostLink([]byte(tt.dest), tt.lang, tt.translated)
+			if string(got) != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestPostLinkTransformer(t *testing.T) {
+	md := goldmark.New(goldmark.WithParserOptions(parser.WithASTTransformers(
+		util.Prioritized(&markdown.PostLinkTransformer{Translated: translatedInFrench}, 0),
+	)))
+	input := "[a](post:2024-01-11-cgo-guide) [b](post:2023-09-10-developing-blog)\n"
+
+	for lang, expected := range map[string][]string{
+		"":   {`href="/blog/2024-01-11-cgo-guide"`, `href="/blog/2023-09-10-developing-blog"`},
+		"fr": {`href="/fr/blog/2024-01-11-cgo-guide"`, `href="/blog/2023-09-10-developing-blog"`},
+	} {
+		pc := parser.NewContext()
+		if lang != "" {
+			pc.Set(markdown.LanguageKey, lang)
+		}
+		var buf bytes.Buffer
+		if err := md.Convert([]byte(input), &buf, parser.WithContext(pc)); err != nil {
+			t.Fatal(err)
+		}
+		for _, e := range expected {
+			if !strings.Contains(buf.String(), e) {
+				t.Errorf("%q: expected %q in:\n%s", lang, e, buf.String())
+			}
+		}
+	}
+}
@@ -9,14 +9,17 @@ import (
 	"net/http"
 	"slices"
 
+	"github.com/Darkness4/blog/utils/i18n"
 	"github.com/Darkness4/blog/web/gen/index"
 	"github.com/rs/zerolog/log"
 )
 
 const (
 	documentsEndpoint = "%s/indexes/%s/documents"
+	deleteEndpoint    = "%s/indexes/%s/documents/delete-batch"
 	tasksEndpoint     = "%s/tasks/%d"
 	searchEndpoint    = "%s/indexes/%s/search"
+	filterEndpoint    = "%s/indexes/%s/settings/filterable-attributes"
 )
 
 type Client struct {
@@ -38,7 +41,11 @@ func NewClient(client *http.Client, url, masterKey, indexUID string) *Client {
 	}
 }
 
-func (c *Client) BuildIndex(ctx context.Context, index [][]index.Index) error {
+func (c *Client) BuildIndex(ctx context.Context, index []index.Index) error {
+	if err := c.setFilterableAttributes(ctx, "lang"); err != nil {
+		return err
+	}
+
 	records := slices.Collect(IndexToRecords(index))
 
 	log.Info().Int("records", len(records)).Msg("building index")
@@ -86,6 +93,45 @@ func (c *Client) BuildIndex(ctx context.Context, index [][]index.Index) error {
 	return c.waitForSuccess(ctx, parsed.TaskUID)
 }
 
+// setFilterableAttributes sets the attributes the search can be filtered on.
+func (c *Client) setFilterableAttributes(ctx context.Context, attributes ...string) error {
+	buf := new(bytes.Buffer)
+	if err := json.NewEncoder(buf).Encode(&attributes); err != nil {
+		return fmt.Errorf("failed to encode attributes to json: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		"PUT",
+		fmt.Sprintf(filterEndpoint, c.URL, c.IndexUID),
+		buf,
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	req.Header.Add("Authorization", "Bearer "+c.MasterKey)
+	req.Header.Add("Content-Type", "application/json")
+	res, err := c.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != 202 {
+		body, _ := io.ReadAll(res.Body)
+		err := fmt.Errorf("failed to set filterable attributes: %v", res.Status)
+		log.Err(err).Str("body", string(body)).Msg("failed to set filterable attributes")
+		return err
+	}
+
+	var parsed SubmittedTaskResponse
+	if err := json.NewDecoder(res.Body).Decode(&parsed); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return c.waitForSuccess(ctx, parsed.TaskUID)
+}
+
 func (c *Client) waitForSuccess(ctx context.Context, uid int) (err error) {
 	var task GetTaskResponse
 	for task.Status != TaskStatusSucceeded && task.Status != TaskStatusCanceled {
@@ -140,6 +186,56 @@ func (c *Client) getTask(ctx context.Context, uid int) (GetTaskResponse, error)
 	return parsed, nil
 }
 
+// DeleteRecords deletes the records of the pages from the index.
+//
+// It is used to remove the pages that are not listed anymore (e.g. a page
+// turned back into a draft).
+func (c *Client) DeleteRecords(ctx context.Context, index []index.Index) error {
+	ids := make([]string, 0, len(index))
+	for record := range IndexToRecords(index) {
+		ids = append(ids, record.ObjectID)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	buf := new(bytes.Buffer)
+	if err := json.NewEncoder(buf).Encode(&ids); err != nil {
+		return fmt.Errorf("failed to encode ids to json: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		"POST",
+		fmt.Sprintf(deleteEndpoint, c.URL, c.IndexUID),
+		buf,
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	req.Header.Add("Authorization", "Bearer "+c.MasterKey)
+	req.Header.Add("Content-Type", "application/json")
+	res, err := c.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != 202 {
+		body, _ := io.ReadAll(res.Body)
+		err := fmt.Errorf("failed to delete records: %v", res.Status)
+		log.Err(err).Str("body", string(body)).Msg("failed to delete records")
+		return err
+	}
+
+	var parsed SubmittedTaskResponse
+	if err := json.NewDecoder(res.Body).Decode(&parsed); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return c.waitForSuccess(ctx, parsed.TaskUID)
+}
+
 func (c *Client) ClearIndex(ctx context.Context) error {
 	req, err := http.NewRequestWithContext(
 		ctx,
@@ -172,13 +268,22 @@ func (c *Client) ClearIndex(ctx context.Context) error {
 	return c.waitForSuccess(ctx, parsed.TaskUID)
 }
 
-func (c *Client) Search(ctx context.Context, query string) (SearchResponse, error) {
+// Search searches the pages in lang, or in every language if lang is empty. It
+// fails if lang is not supported (see i18n.Supported).
+func (c *Client) Search(ctx context.Context, query string, lang string) (SearchResponse, error) {
 	reqBody := SearchRequest{
 		AttributesToHighlight: []string{"*"},
 		AttributesToCrop:      []string{"content"},
 		CropLength:            30,
 		Query:                 query,
 	}
+	if lang != "" {
+		// The language is checked so that it cannot inject a filter.
+		if !i18n.Supported(lang) {
+			return SearchResponse{}, fmt.Errorf("unsupported language: %q", lang)
+		}
+		reqBody.Filter = fmt.Sprintf("lang = %q", lang)
+	}
 
 	var buf bytes.Buffer
 	if err := json.NewEncoder(&buf).Encode(&reqBody); err != nil {
@@ -0,0 +1,14 @@
+package meilisearch_test
+
+import (
+	"testing"
+
+	"github.com/Darkness4/blog/meilisearch"
+)
+
+func TestSearchUnsupportedLanguage(t *testing.T) {
+	c := &meilisearch.Client{}
+	if _, err := c.Search(t.Context(), "go", `en" OR lang != "en`); err == nil {
+		t.Error("expected an error for an unsupported language")
+	}
+}
@@ -24,54 +24,65 @@ type Record struct {
 	Content       string `json:"content"`
 	URL           string `json:"url"`
 	Anchor        string `json:"anchor"`
+	Lang          string `json:"lang"`
+	Series        string `json:"series,omitempty"`
+}
+
+// objectID returns the identifier of the records of a page. The translations
+// are suffixed by their language.
+func objectID(idx index.Index) string {
+	if idx.Lang == index.DefaultLanguage {
+		return idx.EntryName
+	}
+	return idx.EntryName + "-" + idx.Lang
 }
 
 // IndexToRecords converts an Index to a slice of search Records
-func IndexToRecords(index [][]index.Index) iter.Seq[Record] {
+func IndexToRecords(index []index.Index) iter.Seq[Record] {
 
 	return func(yield func(Record) bool) {
-		for _, i := range index {
-			for _, j := range i {
-				// Base hierarchy levels
-				lvl0 := j.PublishedDate.Format("January 2006")
-				lvl1 := j.Title
-
-				// 1. **Directly yield the Level 2 Record**
-				lvl2Record := Record{
-					ObjectID:      j.EntryName,
-					HierarchyLvl0: lvl0,
-					HierarchyLvl1: lvl1,
-					HierarchyLvl2: "",
-					HierarchyLvl3: "",
-					HierarchyLvl4: "",
-					HierarchyLvl5: "",
-					HierarchyLvl6: "",
-					Content:       "",
-					URL:           j.Href,
-					Anchor:        "",
-				}
+		for _, j := range index {
+			// Base hierarchy levels
+			lvl0 := j.PublishedDate.Format("January 2006")
+			lvl1 := j.Title
 
-				if !yield(lvl2Record) {
-					return
-				}
+			// 1. **Directly yield the Level 2 Record**
+			lvl2Record := Record{
+				ObjectID:      objectID(j),
+				HierarchyLvl0: lvl0,
+				HierarchyLvl1: lvl1,
+				HierarchyLvl2: "",
+				HierarchyLvl3: "",
+				HierarchyLvl4: "",
+				HierarchyLvl5: "",
+				HierarchyLvl6: "",
+				Content:       "",
+				URL:           j.Href,
+				Anchor:        "",
+				Lang:          j.Lang,
+				Series:        j.Series,
+			}
+
+			if !yield(lvl2Record) {
+				return
+			}
 
-				// 2. **Pass the yield function to processHeader**
-				for _, header := range j.Hierarchy {
-					// Check if we need to stop based on the return value of processHeader
-					if !processHeader(
-						header,
-						j,
-						lvl0,
-						lvl1,
-						"",
-						"",
-						"",
-						"",
-						"",
-						yield, // Pass the yield function directly
-					) {
-						return // Stop the entire sequence
-					}
+			// 2. **Pass the yield function to processHeader**
+			for _, header := range j.Hierarchy {
+				// Check if we need to stop based on the return value of processHeader
+				if !processHeader(
+					header,
+					j,
+					lvl0,
+					lvl1,
+					"",
+					"",
+					"",
+					"",
+					"",
+					yield, // Pass the yield function directly
+				) {
+					return // Stop the entire sequence
 				}
 			}
 		}
@@ -108,7 +119,7 @@ func processHeader(
 
 	// 1. Create record for this header
 	record := Record{
-		ObjectID:      idx.EntryName + "-" + h.Anchor,
+		ObjectID:      objectID(idx) + "-" + h.Anchor,
 		HierarchyLvl0: lvl0,
 		HierarchyLvl1: lvl1,
 		HierarchyLvl2: lvl2,
@@ -119,6 +130,8 @@ func processHeader(
 		Content:       h.Content,
 		URL:           idx.Href + "#" + h.Anchor,
 		Anchor:        h.Anchor,
+		Lang:          idx.Lang,
+		Series:        idx.Series,
 	}
 
 	// 2. **Directly yield the record**
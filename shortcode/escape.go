@@ -0,0 +1,60 @@
+package shortcode
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// The rendered pages are executed as Go templates at runtime. The runtime
+// actions are delimited by private use characters while rendering, so that
+// Escape can tell them apart from the content.
+const (
+	actionStart = '\uE000'
+	actionEnd   = '\uE001'
+)
+
+var runtimeValue = regexp.MustCompile(`^\$(\.[A-Za-z_][A-Za-z0-9_]*)+$`)
+
+// Action returns a runtime action printing value (e.g. "$.Path"), to be written
+// in the output of a renderer. It panics if value is not a field of the
+// runtime data.
+func Action(value string) string {
+	if !runtimeValue.MatchString(value) {
+		panic("shortcode: invalid runtime value: " + value)
+	}
+	return string(actionStart) + value + string(actionEnd)
+}
+
+// Escape turns a rendered document into a Go template: the literal braces of
+// the content are escaped, and the actions returned by Action are restored.
+//
+// Delimiters that do not enclose a runtime value are dropped.
+func Escape(rendered string) string {
+	var sb strings.Builder
+	sb.Grow(len(rendered))
+	for i := 0; i < len(rendered); {
+		r, size := utf8.DecodeRuneInString(rendered[i:])
+		rest := rendered[i+size:]
+		switch r {
+		case '{':
+			// A "{" is only escaped if it could open an action.
+			if strings.HasPrefix(rest, "{") || strings.HasPrefix(rest, string(actionStart)) {
+				sb.WriteString(`{{"{"}}`)
+			} else {
+				sb.WriteByte('{')
+			}
+		case actionStart:
+			value, _, ok := strings.Cut(rest, string(actionEnd))
+			if ok && runtimeValue.MatchString(value) {
+				sb.WriteString("{{ " + value + " }}")
+				size += len(value) + utf8.RuneLen(actionEnd)
+			}
+		case actionEnd:
+		default:
+			sb.WriteString(rendered[i : i+size])
+		}
+		i += size
+	}
+	return sb.String()
+}
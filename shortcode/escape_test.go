@@ -0,0 +1,59 @@
+package shortcode_test
+
+import (
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/Darkness4/blog/shortcode"
+)
+
+func TestEscape(t *testing.T) {
+	tests := []struct {
+		title    string
+		input    string
+		expected string
+	}{
+		{
+			title:    "Literal braces",
+			input:    "{{ .X }} {{{x}}} {x} }}",
+			expected: "{{ .X }} {{{x}}} {x} }}",
+		},
+		{
+			title:    "Runtime action",
+			input:    shortcode.Action("$.Path") + "/a.png",
+			expected: "/blog/a/a.png",
+		},
+		{
+			title:    "Brace before an action",
+			input:    "{" + shortcode.Action("$.Path") + "}",
+			expected: "{/blog/a}",
+		},
+		{
+			title:    "Unclosed action",
+			input:    "\uE000$.Path",
+			expected: "$.Path",
+		},
+		{
+			title:    "Invalid action",
+			input:    "\uE000 .X \uE001",
+			expected: " .X ",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			tmpl, err := template.New("test").Parse(shortcode.Escape(tt.input))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var sb strings.Builder
+			if err := tmpl.Execute(&sb, struct{ Path string }{Path: "/blog/a"}); err != nil {
+				t.Fatal(err)
+			}
+			if sb.String() != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, sb.String())
+			}
+		})
+	}
+}
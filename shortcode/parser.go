@@ -0,0 +1,177 @@
+package shortcode
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+var (
+	openDelim  = []byte("{{%")
+	closeDelim = []byte("%}}")
+)
+
+type blockParser struct {
+	registry *Registry
+}
+
+// Trigger implements parser.BlockParser interface.
+func (p *blockParser) Trigger() []byte {
+	return []byte{'{'}
+}
+
+// Open implements parser.BlockParser interface.
+func (p *blockParser) Open(
+	_ ast.Node,
+	reader text.Reader,
+	_ parser.Context,
+) (ast.Node, parser.State) {
+	line, segment := reader.PeekLine()
+	trimmed := util.TrimRightSpace(util.TrimLeftSpace(line))
+	if !bytes.HasPrefix(trimmed, openDelim) ||
+		bytes.Index(trimmed, closeDelim) != len(trimmed)-len(closeDelim) {
+		return nil, parser.NoChildren
+	}
+	// An invalid shortcode is reported by the inline parser.
+	c, err := p.registry.parse(trimmed[len(openDelim) : len(trimmed)-len(closeDelim)])
+	if err != nil {
+		return nil, parser.NoChildren
+	}
+	reader.Advance(segment.Len() - 1)
+	n := &Block{call: c}
+	n.Lines().Append(segment)
+	return n, parser.NoChildren
+}
+
+// Continue implements parser.BlockParser interface.
+func (p *blockParser) Continue(ast.Node, text.Reader, parser.Context) parser.State {
+	return parser.Close
+}
+
+// Close implements parser.BlockParser interface.
+func (p *blockParser) Close(ast.Node, text.Reader, parser.Context) {}
+
+// CanInterruptParagraph implements parser.BlockParser interface.
+func (p *blockParser) CanInterruptParagraph() bool {
+	return true
+}
+
+// CanAcceptIndentedLine implements parser.BlockParser interface.
+func (p *blockParser) CanAcceptIndentedLine() bool {
+	return false
+}
+
+type inlineParser struct {
+	registry *Registry
+}
+
+// Trigger implements parser.InlineParser interface.
+func (p *inlineParser) Trigger() []byte {
+	return []byte{'{'}
+}
+
+// Parse implements parser.InlineParser interface.
+func (p *inlineParser) Parse(_ ast.Node, block text.Reader, pc parser.Context) ast.Node {
+	line, segment := block.PeekLine()
+	if !bytes.HasPrefix(line, openDelim) {
+		return nil
+	}
+	end := bytes.Index(line[len(openDelim):], closeDelim)
+	if end < 0 {
+		return nil
+	}
+	c, err := p.registry.parse(line[len(openDelim) : len(openDelim)+end])
+	if err != nil {
+		addError(pc, &Error{
+			Line: lineOf(block.Source(), segment.Start),
+			Name: c.name,
+			Err:  err,
+		})
+		return nil
+	}
+	block.Advance(len(openDelim) + end + len(closeDelim))
+	return &Inline{call: c}
+}
+
+// parse parses the name and the arguments of a shortcode. The name is set even
+// if the arguments are invalid.
+func (r *Registry) parse(b []byte) (call, error) {
+	fields := strings.TrimSpace(string(b))
+	name, rest, _ := strings.Cut(fields, " ")
+	c := call{name: name, args: Args{}}
+	if name == "" {
+		return c, errors.New("missing shortcode name")
+	}
+	s, ok := r.shortcodes[name]
+	if !ok {
+		return c, errors.New("unknown shortcode")
+	}
+	params := make(map[string]Param, len(s.Params))
+	for _, p := range s.Params {
+		params[p.Name] = p
+	}
+
+	var errs []error
+	for rest = strings.TrimSpace(rest); rest != ""; rest = strings.TrimSpace(rest) {
+		key, value, ok := strings.Cut(rest, "=")
+		if !ok || key == "" || strings.ContainsFunc(key, unicode.IsSpace) {
+			return c, fmt.Errorf("invalid argument: %s", rest)
+		}
+		var raw string
+		if strings.HasPrefix(value, `"`) {
+			quoted, err := strconv.QuotedPrefix(value)
+			if err != nil {
+				return c, fmt.Errorf("%s: invalid string: %w", key, err)
+			}
+			raw, _ = strconv.Unquote(quoted)
+			rest = value[len(quoted):]
+		} else {
+			raw, rest, _ = strings.Cut(value, " ")
+		}
+
+		p, ok := params[key]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: unknown argument", key))
+			continue
+		}
+		if _, ok := c.args[key]; ok {
+			errs = append(errs, fmt.Errorf("%s: duplicate argument", key))
+			continue
+		}
+		v, err := convert(p.Kind, raw)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: expected a %s: %w", key, p.Kind, err))
+			continue
+		}
+		c.args[key] = v
+	}
+	for _, p := range s.Params {
+		if _, ok := c.args[p.Name]; p.Required && !ok {
+			errs = append(errs, fmt.Errorf("%s: missing required argument", p.Name))
+		}
+	}
+	return c, errors.Join(errs...)
+}
+
+func convert(kind Kind, raw string) (any, error) {
+	switch kind {
+	case Int:
+		return strconv.Atoi(raw)
+	case Bool:
+		return strconv.ParseBool(raw)
+	default:
+		return raw, nil
+	}
+}
+
+func lineOf(source []byte, offset int) int {
+	return bytes.Count(source[:offset], []byte("\n")) + 1
+}
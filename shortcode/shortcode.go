@@ -0,0 +1,250 @@
+// Package shortcode is a extension for the goldmark (http://github.com/yuin/goldmark).
+//
+// This extension adds shortcodes: named and typed directives rendered at build
+// time, written {{% name key=value %}}.
+//
+// A shortcode on its own line is a block, otherwise it is inline. Shortcodes
+// are not parsed in code spans and code blocks.
+package shortcode
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/util"
+)
+
+// Kind is the type of a parameter.
+type Kind int
+
+const (
+	// String is a quoted or a bare string, e.g. name="a b" or name=a.
+	String Kind = iota
+	// Int is an integer, e.g. count=3.
+	Int
+	// Bool is a boolean, e.g. open=true.
+	Bool
+)
+
+func (k Kind) String() string {
+	switch k {
+	case String:
+		return "string"
+	case Int:
+		return "int"
+	case Bool:
+		return "bool"
+	}
+	return "Kind(" + strconv.Itoa(int(k)) + ")"
+}
+
+// Param is a parameter of a shortcode.
+type Param struct {
+	Name     string
+	Kind     Kind
+	Required bool
+}
+
+// Args are the arguments of a shortcode, typed according to its parameters.
+type Args map[string]any
+
+// String returns the argument name, or "" if it is not set.
+func (a Args) String(name string) string {
+	s, _ := a[name].(string)
+	return s
+}
+
+// Int returns the argument name, or 0 if it is not set.
+func (a Args) Int(name string) int {
+	i, _ := a[name].(int)
+	return i
+}
+
+// Bool returns the argument name, or false if it is not set.
+func (a Args) Bool(name string) bool {
+	b, _ := a[name].(bool)
+	return b
+}
+
+// RenderFunc renders a shortcode.
+//
+// The output is escaped by Escape, runtime values must be written with Action.
+type RenderFunc func(w util.BufWriter, source []byte, n Node) error
+
+// Shortcode is a named directive.
+type Shortcode struct {
+	Name   string
+	Params []Param
+	Render RenderFunc
+}
+
+// Error is an invalid shortcode.
+type Error struct {
+	Path string
+	Line int
+	Name string
+	Err  error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s:%d: %s: %v", e.Path, e.Line, e.Name, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+var errorsKey = parser.NewContextKey()
+
+// Errors returns the invalid shortcodes found while parsing with pc. Their Path
+// is left empty.
+func Errors(pc parser.Context) []*Error {
+	errs, _ := pc.Get(errorsKey).([]*Error)
+	return errs
+}
+
+func addError(pc parser.Context, err *Error) {
+	pc.Set(errorsKey, append(Errors(pc), err))
+}
+
+// Registry is a set of shortcodes and a goldmark extension.
+type Registry struct {
+	shortcodes map[string]Shortcode
+}
+
+// New returns a registry of shortcodes.
+func New(shortcodes ...Shortcode) *Registry {
+	r := &Registry{shortcodes: make(map[string]Shortcode, len(shortcodes))}
+	for _, s := range shortcodes {
+		r.shortcodes[s.Name] = s
+	}
+	return r
+}
+
+// Extend implements goldmark.Extender interface.
+func (r *Registry) Extend(m goldmark.Markdown) {
+	m.Parser().AddOptions(
+		parser.WithBlockParsers(
+			util.Prioritized(&blockParser{registry: r}, 90),
+		),
+		parser.WithInlineParsers(
+			util.Prioritized(&inlineParser{registry: r}, 90),
+		),
+	)
+	m.Renderer().AddOptions(renderer.WithNodeRenderers(
+		util.Prioritized(&nodeRenderer{registry: r}, 0),
+	))
+}
+
+// Node is a shortcode in the document.
+type Node interface {
+	ast.Node
+	// Shortcode returns the name and the arguments of the shortcode.
+	Shortcode() (name string, args Args)
+}
+
+type call struct {
+	name string
+	args Args
+}
+
+func (c *call) Shortcode() (string, Args) {
+	return c.name, c.args
+}
+
+func (c *call) dump(n ast.Node, source []byte, level int) {
+	kv := map[string]string{"Name": c.name}
+	for k, v := range c.args {
+		kv[k] = fmt.Sprint(v)
+	}
+	ast.DumpHelper(n, source, level, kv, nil)
+}
+
+// KindBlock is a NodeKind of the Block node.
+var KindBlock = ast.NewNodeKind("ShortcodeBlock")
+
+// Block is a shortcode on its own line.
+type Block struct {
+	ast.BaseBlock
+	call
+}
+
+// Kind implements Node.Kind.
+func (n *Block) Kind() ast.NodeKind {
+	return KindBlock
+}
+
+// IsRaw implements Node.IsRaw.
+func (n *Block) IsRaw() bool {
+	return true
+}
+
+// Dump implements Node.Dump.
+func (n *Block) Dump(source []byte, level int) {
+	n.dump(n, source, level)
+}
+
+// KindInline is a NodeKind of the Inline node.
+var KindInline = ast.NewNodeKind("ShortcodeInline")
+
+// Inline is a shortcode within a text.
+type Inline struct {
+	ast.BaseInline
+	call
+}
+
+// Kind implements Node.Kind.
+func (n *Inline) Kind() ast.NodeKind {
+	return KindInline
+}
+
+// Dump implements Node.Dump.
+func (n *Inline) Dump(source []byte, level int) {
+	n.dump(n, source, level)
+}
+
+type nodeRenderer struct {
+	registry *Registry
+}
+
+// RegisterFuncs implements NodeRenderer.RegisterFuncs interface.
+func (r *nodeRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(KindBlock, r.render)
+	reg.Register(KindInline, r.render)
+}
+
+func (r *nodeRenderer) render(
+	w util.BufWriter,
+	source []byte,
+	node ast.Node,
+	entering bool,
+) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+	n := node.(Node)
+	name, _ := n.Shortcode()
+	if err := r.registry.shortcodes[name].Render(w, source, n); err != nil {
+		return ast.WalkStop, fmt.Errorf("%s: %w", name, err)
+	}
+	return ast.WalkSkipChildren, nil
+}
+
+// Runtime prints a value of the runtime data, e.g. {{% runtime value="$.Path" %}}.
+var Runtime = Shortcode{
+	Name:   "runtime",
+	Params: []Param{{Name: "value", Kind: String, Required: true}},
+	Render: func(w util.BufWriter, _ []byte, n Node) error {
+		_, args := n.Shortcode()
+		value := args.String("value")
+		if !runtimeValue.MatchString(value) {
+			return fmt.Errorf("invalid runtime value: %s", value)
+		}
+		_, err := w.WriteString(Action(value))
+		return err
+	},
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 	"time"
+	"unicode"
 )
 
 func ExtractDate(filename string) (time.Time, error) {
@@ -27,3 +28,21 @@ func ExtractDate(filename string) (time.Time, error) {
 
 	return date, nil
 }
+
+// Slug returns the URL path segment of a name, e.g. "Go & CGO" -> "go-cgo".
+func Slug(name string) string {
+	var sb strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if dash && sb.Len() > 0 {
+				sb.WriteByte('-')
+			}
+			sb.WriteRune(r)
+			dash = false
+		} else {
+			dash = true
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,55 @@
+package blog
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/yuin/goldmark/ast"
+)
+
+// excerptLength is the maximum length of an excerpt, in runes.
+const excerptLength = 160
+
+// Excerpt returns the beginning of the first paragraph of a markdown document,
+// as plain text.
+func Excerpt(doc ast.Node, source []byte) string {
+	for n := doc.FirstChild(); n != nil; n = n.NextSibling() {
+		if n.Kind() != ast.KindParagraph {
+			continue
+		}
+		var sb strings.Builder
+		_ = ast.Walk(n, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+			if !entering {
+				return ast.WalkContinue, nil
+			}
+			switch n := n.(type) {
+			case *ast.Text:
+				sb.Write(n.Value(source))
+				if n.SoftLineBreak() || n.HardLineBreak() {
+					sb.WriteByte(' ')
+				}
+			case *ast.String:
+				sb.Write(n.Value)
+			case *ast.RawHTML:
+				return ast.WalkSkipChildren, nil
+			}
+			return ast.WalkContinue, nil
+		})
+		if text := strings.Join(strings.Fields(sb.String()), " "); text != "" {
+			return truncate(text, excerptLength)
+		}
+	}
+	return ""
+}
+
+// truncate cuts s at a word boundary so that it is at most n runes long.
+func truncate(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	runes := []rune(s)[:n]
+	if i := strings.LastIndexByte(string(runes), ' '); i > 0 {
+		return string(runes)[:i] + "…"
+	}
+	return string(runes) + "…"
+}
@@ -0,0 +1,358 @@
+package blog
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FrontMatter is the metadata of a page, written in YAML between two "---"
+// lines at the top of the page.
+//
+//	---
+//	title: Using C libraries in Go with CGO
+//	description: Simple guide and recommendations about CGO.
+//	tags: [go, cgo, ffi, c]
+//	updated: 2024-02-01
+//	authors: [Marc Nguyen]
+//	canonical: https://example.com/cgo-guide
+//	series: {name: CGO, order: 1}
+//	d2: {layout: elk, sketch: true}
+//	---
+type FrontMatter struct {
+	Title string
+	// Description is the summary of the page. If it is not set, an excerpt of
+	// the content should be used instead (see Excerpt).
+	Description string
+	Tags        []string
+	Updated     time.Time
+	Authors     []string
+	// Canonical is the canonical URL of the page, if it was first published
+	// elsewhere.
+	Canonical string
+	// Series is the series the page is part of, if any.
+	Series *Series
+	// D2 are the default options of the d2 diagrams of the page.
+	D2 DiagramOptions
+
+	Visibility
+}
+
+// Series is the membership of a page in a series of articles.
+type Series struct {
+	Name string
+	// Order is the position of the page in the series, starting at 1.
+	Order int
+}
+
+// DiagramOptions are the options of the d2 diagrams. The unset options are
+// inherited.
+type DiagramOptions struct {
+	// Layout is the layout engine: "dagre" or "elk".
+	Layout string
+	// Theme and DarkTheme are d2 theme IDs. Diagrams with a dark theme are
+	// rendered twice, the version shown follows prefers-color-scheme.
+	Theme     *int64
+	DarkTheme *int64
+	Sketch    *bool
+	Pad       *int64
+	Scale     *float64
+	Center    *bool
+	// Animate is the number of milliseconds each board of a diagram with
+	// several boards is shown in an animated SVG. If not set, the boards are
+	// shown in a stepper.
+	Animate *int64
+}
+
+// FrontMatterError is an error in the front-matter of a page.
+type FrontMatterError struct {
+	Path  string
+	Line  int
+	Field string
+	Err   error
+}
+
+func (e *FrontMatterError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("%s:%d: %v", e.Path, e.Line, e.Err)
+	}
+	return fmt.Sprintf("%s:%d: %s: %v", e.Path, e.Line, e.Field, e.Err)
+}
+
+func (e *FrontMatterError) Unwrap() error {
+	return e.Err
+}
+
+// ParseFrontMatter parses and validates the front-matter of the page at path.
+//
+// Every error is reported, each one as a *FrontMatterError.
+func ParseFrontMatter(path string, source []byte) (fm FrontMatter, err error) {
+	block, ok := frontMatterBlock(source)
+	if !ok {
+		return fm, &FrontMatterError{Path: path, Line: 1, Err: errors.New("missing front-matter")}
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(block, &doc); err != nil {
+		var line int
+		// yaml.v3 doesn't expose the position of syntax errors.
+		_, _ = fmt.Sscanf(err.Error(), "yaml: line %d:", &line)
+		if yamlParserError.MatchString(err.Error()) {
+			line++
+		}
+		return fm, &FrontMatterError{Path: path, Line: line + 1, Err: err}
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return fm, &FrontMatterError{Path: path, Line: 2, Err: errors.New("expected a mapping")}
+	}
+	root := doc.Content[0]
+
+	var errs []error
+	seen := make(map[string]bool)
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+		var err error
+		switch {
+		case seen[key.Value]:
+			err = errors.New("duplicated field")
+		case key.Value == "title":
+			fm.Title, err = decodeString(value)
+			if err == nil && fm.Title == "" {
+				err = errors.New("must not be empty")
+			}
+		case key.Value == "description":
+			fm.Description, err = decodeString(value)
+		case key.Value == "tags":
+			fm.Tags, err = decodeStrings(value)
+		case key.Value == "updated":
+			fm.Updated, err = decodeDate(value)
+		case key.Value == "authors":
+			fm.Authors, err = decodeStrings(value)
+		case key.Value == "canonical":
+			fm.Canonical, err = decodeString(value)
+			if err == nil {
+				err = validateURL(fm.Canonical)
+			}
+		case key.Value == "series":
+			fm.Series, err = decodeSeries(value)
+		case key.Value == "d2":
+			fm.D2, err = decodeDiagramOptions(value)
+		case key.Value == "draft":
+			fm.Draft, err = decodeBool(value)
+		case key.Value == "publishAt":
+			fm.PublishAt, err = decodeDate(value)
+		case key.Value == "unlisted":
+			fm.Unlisted, err = decodeBool(value)
+		default:
+			err = errors.New("unknown field")
+		}
+		seen[key.Value] = true
+		if err != nil {
+			errs = append(errs, &FrontMatterError{
+				Path:  path,
+				Line:  value.Line + 1, // Offset of the opening "---".
+				Field: key.Value,
+				Err:   err,
+			})
+		}
+	}
+	if !seen["title"] {
+		errs = append(errs, &FrontMatterError{
+			Path:  path,
+			Line:  1,
+			Field: "title",
+			Err:   errors.New("missing required field"),
+		})
+	}
+
+	return fm, errors.Join(errs...)
+}
+
+// yamlParserError matches the syntax errors yaml.v3 reports with a line
+// numbered from 0, unlike the errors of its scanner.
+var yamlParserError = regexp.MustCompile(`did not find expected (key|node content|'-' indicator|',' or '[\]}]'|<document start>)$`)
+
+// frontMatterBlock returns the YAML between the "---" lines.
+func frontMatterBlock(source []byte) ([]byte, bool) {
+	first, rest, ok := bytes.Cut(source, []byte("\n"))
+	if !ok || !isSeparator(first) {
+		return nil, false
+	}
+	var block []byte
+	for len(rest) > 0 {
+		var line []byte
+		line, rest, _ = bytes.Cut(rest, []byte("\n"))
+		if isSeparator(line) {
+			return block, true
+		}
+		block = append(block, line...)
+		block = append(block, '\n')
+	}
+	return nil, false
+}
+
+func isSeparator(line []byte) bool {
+	line = bytes.TrimSpace(line)
+	return len(line) >= 3 && len(bytes.Trim(line, "-")) == 0
+}
+
+func decodeString(n *yaml.Node) (string, error) {
+	if n.Kind != yaml.ScalarNode || n.Tag == "!!null" {
+		return "", errors.New("expected a string")
+	}
+	return n.Value, nil
+}
+
+func decodeStrings(n *yaml.Node) ([]string, error) {
+	if n.Kind != yaml.SequenceNode {
+		return nil, errors.New("expected a list of strings")
+	}
+	out := make([]string, 0, len(n.Content))
+	for _, item := range n.Content {
+		s, err := decodeString(item)
+		if err != nil || s == "" {
+			return nil, fmt.Errorf("line %d: expected a non-empty string", item.Line+1)
+		}
+		out = append(out, s)
+	}
+	return out, nil
+}
+
+func decodeBool(n *yaml.Node) (b bool, err error) {
+	if n.Kind != yaml.ScalarNode || n.Tag != "!!bool" {
+		return false, errors.New("expected a boolean")
+	}
+	err = n.Decode(&b)
+	return b, err
+}
+
+func decodeSeries(n *yaml.Node) (*Series, error) {
+	if n.Kind != yaml.MappingNode {
+		return nil, errors.New("expected a mapping with a name and an order")
+	}
+	var series Series
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key, value := n.Content[i], n.Content[i+1]
+		var err error
+		switch key.Value {
+		case "name":
+			series.Name, err = decodeString(value)
+		case "order":
+			if value.Kind != yaml.ScalarNode || value.Tag != "!!int" {
+				err = errors.New("expected an integer")
+			} else {
+				err = value.Decode(&series.Order)
+			}
+		default:
+			err = errors.New("unknown field")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s: %w", value.Line+1, key.Value, err)
+		}
+	}
+	if series.Name == "" {
+		return nil, errors.New("name: must not be empty")
+	}
+	if series.Order < 1 {
+		return nil, errors.New("order: must be a positive integer")
+	}
+	return &series, nil
+}
+
+func decodeDiagramOptions(n *yaml.Node) (opts DiagramOptions, err error) {
+	if n.Kind != yaml.MappingNode {
+		return opts, errors.New("expected a mapping of d2 options")
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key, value := n.Content[i], n.Content[i+1]
+		var err error
+		switch key.Value {
+		case "layout":
+			opts.Layout, err = decodeString(value)
+			if err == nil && opts.Layout != "dagre" && opts.Layout != "elk" {
+				err = fmt.Errorf("unknown layout %q, expected dagre or elk", opts.Layout)
+			}
+		case "theme":
+			opts.Theme, err = decodeNonNegativeInt(value)
+		case "dark-theme":
+			opts.DarkTheme, err = decodeNonNegativeInt(value)
+		case "sketch":
+			opts.Sketch, err = decodeBoolRef(value)
+		case "pad":
+			opts.Pad, err = decodeNonNegativeInt(value)
+		case "scale":
+			var scale float64
+			if value.Kind != yaml.ScalarNode || (value.Tag != "!!float" && value.Tag != "!!int") {
+				err = errors.New("expected a number")
+			} else if err = value.Decode(&scale); err == nil && scale <= 0 {
+				err = errors.New("must be positive")
+			}
+			opts.Scale = &scale
+		case "center":
+			opts.Center, err = decodeBoolRef(value)
+		case "animate":
+			opts.Animate, err = decodeNonNegativeInt(value)
+		default:
+			err = errors.New("unknown field")
+		}
+		if err != nil {
+			return opts, fmt.Errorf("line %d: %s: %w", value.Line+1, key.Value, err)
+		}
+	}
+	return opts, nil
+}
+
+func decodeNonNegativeInt(n *yaml.Node) (*int64, error) {
+	var i int64
+	if n.Kind != yaml.ScalarNode || n.Tag != "!!int" {
+		return nil, errors.New("expected an integer")
+	}
+	if err := n.Decode(&i); err != nil {
+		return nil, err
+	}
+	if i < 0 {
+		return nil, errors.New("must not be negative")
+	}
+	return &i, nil
+}
+
+func decodeBoolRef(n *yaml.Node) (*bool, error) {
+	b, err := decodeBool(n)
+	return &b, err
+}
+
+var dateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+func decodeDate(n *yaml.Node) (time.Time, error) {
+	if n.Kind != yaml.ScalarNode {
+		return time.Time{}, errors.New("expected a date")
+	}
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, n.Value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD or RFC 3339", n.Value)
+}
+
+func validateURL(s string) error {
+	u, err := url.Parse(s)
+	if err != nil {
+		return err
+	}
+	if !u.IsAbs() || u.Host == "" {
+		return fmt.Errorf("expected an absolute URL, got %q", s)
+	}
+	return nil
+}
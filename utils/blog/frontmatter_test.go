@@ -0,0 +1,205 @@
+package blog_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Darkness4/blog/utils/blog"
+)
+
+func TestParseFrontMatter(t *testing.T) {
+	tests := []struct {
+		title    string
+		input    string
+		expected blog.FrontMatter
+	}{
+		{
+			title: "Full",
+			input: "---\n" +
+				"title: Using C libraries in Go with CGO\n" +
+				"description: Simple guide.\n" +
+				"tags: [go, cgo]\n" +
+				"updated: 2024-02-01\n" +
+				"authors: [Marc Nguyen]\n" +
+				"canonical: https://example.com/cgo-guide\n" +
+				"---\n\nContent\n",
+			expected: blog.FrontMatter{
+				Title:       "Using C libraries in Go with CGO",
+				Description: "Simple guide.",
+				Tags:        []string{"go", "cgo"},
+				Updated:     time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+				Authors:     []string{"Marc Nguyen"},
+				Canonical:   "https://example.com/cgo-guide",
+			},
+		},
+		{
+			title:    "Title only",
+			input:    "---\ntitle: Title\n---\n",
+			expected: blog.FrontMatter{Title: "Title"},
+		},
+		{
+			title:    "Date and time",
+			input:    "---\ntitle: Title\nupdated: 2024-02-01 10:30\n---\n",
+			expected: blog.FrontMatter{Title: "Title", Updated: time.Date(2024, 2, 1, 10, 30, 0, 0, time.UTC)},
+		},
+		{
+			title: "Series in flow style",
+			input: "---\ntitle: Title\nseries: {name: CGO, order: 2}\n---\n",
+			expected: blog.FrontMatter{
+				Title:  "Title",
+				Series: &blog.Series{Name: "CGO", Order: 2},
+			},
+		},
+		{
+			title: "Series in block style",
+			input: "---\ntitle: Title\nseries:\n  name: CGO\n  order: 1\n---\n",
+			expected: blog.FrontMatter{
+				Title:  "Title",
+				Series: &blog.Series{Name: "CGO", Order: 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			fm, err := blog.ParseFrontMatter("page.md", []byte(tt.input))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(fm, tt.expected) {
+				t.Errorf("expected %+v, got %+v", tt.expected, fm)
+			}
+		})
+	}
+}
+
+func TestParseFrontMatterErrors(t *testing.T) {
+	tests := []struct {
+		title    string
+		input    string
+		expected []string
+	}{
+		{
+			title:    "Missing front-matter",
+			input:    "# Title\n",
+			expected: []string{"page.md:1: missing front-matter"},
+		},
+		{
+			title:    "Unterminated front-matter",
+			input:    "---\ntitle: Title\n",
+			expected: []string{"page.md:1: missing front-matter"},
+		},
+		{
+			title:    "Not a mapping",
+			input:    "---\n- title\n---\n",
+			expected: []string{"page.md:2: expected a mapping"},
+		},
+		{
+			title:    "Missing title",
+			input:    "---\ndescription: Description\n---\n",
+			expected: []string{"page.md:1: title: missing required field"},
+		},
+		{
+			title:    "Empty title",
+			input:    "---\ntitle: \"\"\n---\n",
+			expected: []string{"page.md:2: title: must not be empty"},
+		},
+		{
+			title:    "Unknown field",
+			input:    "---\ntitle: Title\n\nsubtitle: Subtitle\n---\n",
+			expected: []string{"page.md:4: subtitle: unknown field"},
+		},
+		{
+			title:    "Duplicated field",
+			input:    "---\ntitle: Title\ntitle: Again\n---\n",
+			expected: []string{"page.md:3: title: duplicated field"},
+		},
+		{
+			title:    "Bad date",
+			input:    "---\ntitle: Title\nupdated: 01/02/2024\n---\n",
+			expected: []string{`page.md:3: updated: invalid date "01/02/2024", expected YYYY-MM-DD or RFC 3339`},
+		},
+		{
+			title:    "Bad tags",
+			input:    "---\ntitle: Title\ntags:\n  - go\n  - \"\"\n---\n",
+			expected: []string{"page.md:4: tags: line 5: expected a non-empty string"},
+		},
+		{
+			title:    "Relative canonical",
+			input:    "---\ntitle: Title\ncanonical: /blog/post\n---\n",
+			expected: []string{`page.md:3: canonical: expected an absolute URL, got "/blog/post"`},
+		},
+		{
+			title:    "Scanner syntax error",
+			input:    "---\ntitle: Title\n\tdescription: Description\n---\n",
+			expected: []string{"page.md:3: yaml:"},
+		},
+		{
+			title:    "Parser syntax error",
+			input:    "---\ntitle: Title\ntags: [go\n---\n",
+			expected: []string{"page.md:3: yaml:"},
+		},
+		{
+			title:    "Parser syntax error in a mapping",
+			input:    "---\ntitle: Title\n- go\n---\n",
+			expected: []string{"page.md:3: yaml:"},
+		},
+		{
+			title: "Every error",
+			input: "---\ndescription: [a]\nupdated: never\n---\n",
+			expected: []string{
+				"page.md:2: description: expected a string",
+				`page.md:3: updated: invalid date "never"`,
+				"page.md:1: title: missing required field",
+			},
+		},
+		{
+			title:    "Series as a string",
+			input:    "---\ntitle: Title\nseries: CGO\n---\n",
+			expected: []string{"page.md:3: series: expected a mapping with a name and an order"},
+		},
+		{
+			title:    "Series without order",
+			input:    "---\ntitle: Title\nseries: {name: CGO}\n---\n",
+			expected: []string{"page.md:3: series: order: must be a positive integer"},
+		},
+		{
+			title:    "Series without name",
+			input:    "---\ntitle: Title\nseries: {order: 1}\n---\n",
+			expected: []string{"page.md:3: series: name: must not be empty"},
+		},
+		{
+			title:    "Series with a bad order",
+			input:    "---\ntitle: Title\nseries:\n  name: CGO\n  order: first\n---\n",
+			expected: []string{"page.md:4: series: line 5: order: expected an integer"},
+		},
+		{
+			title:    "Series with an unknown field",
+			input:    "---\ntitle: Title\nseries: {name: CGO, order: 1, part: 1}\n---\n",
+			expected: []string{"page.md:3: series: line 3: part: unknown field"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			_, err := blog.ParseFrontMatter("page.md", []byte(tt.input))
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			var fmErr *blog.FrontMatterError
+			if !errors.As(err, &fmErr) {
+				t.Errorf("expected a *blog.FrontMatterError, got %T", err)
+			}
+			lines := strings.Split(err.Error(), "\n")
+			if len(lines) != len(tt.expected) {
+				t.Fatalf("expected %d errors, got:\n%v", len(tt.expected), err)
+			}
+			for i, e := range tt.expected {
+				if !strings.HasPrefix(lines[i], e) {
+					t.Errorf("expected %q, got %q", e, lines[i])
+				}
+			}
+		})
+	}
+}
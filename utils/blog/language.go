@@ -0,0 +1,45 @@
+package blog
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/Darkness4/blog/utils/i18n"
+)
+
+// PageLanguage returns the language of a page from its file name: page.md is
+// in the default language and page.<lang>.md is a translation.
+func PageLanguage(name string) (lang string, ok bool) {
+	if name == "page.md" {
+		return i18n.Default, true
+	}
+	lang, ok = strings.CutPrefix(name, "page.")
+	if !ok {
+		return "", false
+	}
+	lang, ok = strings.CutSuffix(lang, ".md")
+	if !ok || lang == "" || strings.Contains(lang, ".") {
+		return "", false
+	}
+	return lang, true
+}
+
+// LanguagePrefix returns the path prefix of the pages in lang.
+func LanguagePrefix(lang string) string {
+	if lang == i18n.Default {
+		return ""
+	}
+	return "/" + lang
+}
+
+// Href returns the URL path of a page from its file, e.g.
+// pages/blog/2024-01-11-cgo-guide/page.fr.md -> /fr/blog/2024-01-11-cgo-guide.
+func Href(file string) string {
+	lang, _ := PageLanguage(filepath.Base(file))
+	dir := strings.TrimPrefix(filepath.Dir(file), "pages")
+	href := LanguagePrefix(lang) + filepath.Join("/", dir)
+	if len(href) > 1 {
+		href = strings.TrimSuffix(href, "/")
+	}
+	return href
+}
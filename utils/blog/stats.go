@@ -0,0 +1,93 @@
+package blog
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"time"
+
+	mathjax "github.com/litao91/goldmark-mathjax"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/util"
+)
+
+// Reading rates of the content of technical articles.
+const (
+	wordsPerMinute     = 200
+	codeLinesPerMinute = 20
+	secondsPerDiagram  = 30
+)
+
+// Stats are the statistics of the content of a page.
+type Stats struct {
+	// Words is the number of words of the prose, excluding the code, the
+	// diagrams, the math, the URLs and the raw HTML.
+	Words int
+	// CodeLines is the number of non-blank lines of the code blocks.
+	CodeLines int
+	// Diagrams is the number of d2 diagrams.
+	Diagrams int
+}
+
+// CountStats counts the content of a markdown document. The document must be
+// parsed without the extensions replacing the code blocks, and with
+// goldmark-mathjax so that the math is not counted as words, e.g. with
+// goldmark-meta and goldmark-mathjax only.
+func CountStats(doc ast.Node, source []byte) Stats {
+	var s Stats
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch n.Kind() {
+		case mathjax.KindInlineMath, mathjax.KindMathBlock:
+			return ast.WalkSkipChildren, nil
+		}
+		switch n := n.(type) {
+		case *ast.FencedCodeBlock:
+			if bytes.Equal(n.Language(source), []byte("d2")) {
+				s.Diagrams++
+			} else {
+				s.CodeLines += countLines(n, source)
+			}
+			return ast.WalkSkipChildren, nil
+		case *ast.CodeBlock:
+			s.CodeLines += countLines(n, source)
+			return ast.WalkSkipChildren, nil
+		case *ast.CodeSpan:
+			s.Words++
+			return ast.WalkSkipChildren, nil
+		case *ast.AutoLink, *ast.RawHTML, *ast.HTMLBlock:
+			return ast.WalkSkipChildren, nil
+		case *ast.Text:
+			s.Words += len(strings.Fields(string(n.Value(source))))
+		case *ast.String:
+			s.Words += len(strings.Fields(string(n.Value)))
+		}
+		return ast.WalkContinue, nil
+	})
+	return s
+}
+
+// countLines returns the number of non-blank lines of a block.
+func countLines(n ast.Node, source []byte) int {
+	count := 0
+	lines := n.Lines()
+	for i := 0; i < lines.Len(); i++ {
+		segment := lines.At(i)
+		if !util.IsBlank(segment.Value(source)) {
+			count++
+		}
+	}
+	return count
+}
+
+// ReadingTime is the estimated time to read the page, rounded to the minute.
+// It is at least one minute.
+func (s Stats) ReadingTime() time.Duration {
+	seconds := float64(s.Words)*60/wordsPerMinute +
+		float64(s.CodeLines)*60/codeLinesPerMinute +
+		float64(s.Diagrams)*secondsPerDiagram
+	minutes := max(1, math.Round(seconds/60))
+	return time.Duration(minutes) * time.Minute
+}
@@ -0,0 +1,57 @@
+package blog_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Darkness4/blog/utils/blog"
+	mathjax "github.com/litao91/goldmark-mathjax"
+	"github.com/yuin/goldmark"
+	meta "github.com/yuin/goldmark-meta"
+	"github.com/yuin/goldmark/text"
+)
+
+func TestCountStats(t *testing.T) {
+	source := []byte("---\ntitle: Not counted\n---\n\n" +
+		"# Hello world\n\n" +
+		"Some *emphasized* prose with `code` and [a link](https://example.com/very/long) here.\n\n" +
+		"See <https://example.com> and <span>raw</span> HTML.\n\n" +
+		"<div>\nblock html words here\n</div>\n\n" +
+		"Inline math $a + b = c$ and:\n\n" +
+		"$$\nx^2 + y^2\n$$\n\n" +
+		"```go\npackage main\n\nfunc main() {}\n```\n\n" +
+		"    indented code\n\n" +
+		"```d2\na -> b\n```\n")
+	doc := goldmark.New(goldmark.WithExtensions(meta.Meta, mathjax.MathJax)).Parser().Parse(text.NewReader(source))
+
+	// Hello world (2), Some emphasized prose with code and a link here. (9),
+	// See and raw HTML. (4) and Inline math and: (3).
+	expected := blog.Stats{Words: 18, CodeLines: 3, Diagrams: 1}
+	got := blog.CountStats(doc, source)
+	if got != expected {
+		t.Errorf("expected %+v, got %+v", expected, got)
+	}
+	// 18 words (5.4s), 3 lines of code (9s) and a diagram (30s).
+	if rt := got.ReadingTime(); rt != time.Minute {
+		t.Errorf("expected a reading time of 1m, got %v", rt)
+	}
+}
+
+func TestReadingTime(t *testing.T) {
+	tests := []struct {
+		stats    blog.Stats
+		expected time.Duration
+	}{
+		{stats: blog.Stats{}, expected: time.Minute},
+		{stats: blog.Stats{Words: 400}, expected: 2 * time.Minute},
+		{stats: blog.Stats{Words: 500}, expected: 3 * time.Minute},
+		{stats: blog.Stats{CodeLines: 30}, expected: 2 * time.Minute},
+		{stats: blog.Stats{Diagrams: 5}, expected: 3 * time.Minute},
+		{stats: blog.Stats{Words: 200, CodeLines: 20, Diagrams: 2}, expected: 3 * time.Minute},
+	}
+	for _, tt := range tests {
+		if got := tt.stats.ReadingTime(); got != tt.expected {
+			t.Errorf("%+v: expected %v, got %v", tt.stats, tt.expected, got)
+		}
+	}
+}
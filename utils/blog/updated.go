@@ -0,0 +1,92 @@
+package blog
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// IgnoreRevsFile is the file at the root of the repository listing the commits
+// which do not update the pages they touch (imports, mechanical rewrites...),
+// one full hash per line, as the --ignore-revs-file of git blame.
+const IgnoreRevsFile = ".git-blame-ignore-revs"
+
+// CommitDates are the dates of the last commits touching the pages, from git.
+// A nil *CommitDates has no dates.
+type CommitDates struct {
+	ignored map[string]bool
+}
+
+// NewCommitDates returns the commit dates of the git repository of the
+// current directory, without the commits of its IgnoreRevsFile.
+//
+// It fails if git is not available or if the repository is shallow, as the
+// last commits touching the pages would be missing.
+func NewCommitDates() (*CommitDates, error) {
+	out, err := exec.Command("git", "rev-parse", "--is-shallow-repository", "--show-toplevel").Output()
+	if err != nil {
+		return nil, fmt.Errorf("git: %w", err)
+	}
+	shallow, top, _ := strings.Cut(strings.TrimSpace(string(out)), "\n")
+	if shallow == "true" {
+		return nil, errors.New("git: shallow repository, its whole history must be fetched")
+	}
+	b, err := os.ReadFile(filepath.Join(top, IgnoreRevsFile))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	c := &CommitDates{ignored: make(map[string]bool)}
+	for line := range strings.Lines(string(b)) {
+		rev, _, _ := strings.Cut(line, "#")
+		if rev = strings.TrimSpace(rev); rev != "" {
+			c.ignored[rev] = true
+		}
+	}
+	return c, nil
+}
+
+// Last returns the author date of the last commit touching path, besides the
+// ignored ones. It returns a zero time if there is none.
+func (c *CommitDates) Last(path string) (time.Time, error) {
+	if c == nil {
+		return time.Time{}, nil
+	}
+	out, err := exec.Command("git", "log", "--format=%H %at", "--", path).Output()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("git: %w", err)
+	}
+	for line := range strings.Lines(string(out)) {
+		rev, at, _ := strings.Cut(strings.TrimSpace(line), " ")
+		if c.ignored[rev] {
+			continue
+		}
+		sec, err := strconv.ParseInt(at, 10, 64)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return time.Unix(sec, 0).UTC(), nil
+	}
+	return time.Time{}, nil
+}
+
+// UpdatedDate returns the date a page was last updated: the "updated" field
+// of its front-matter, or else the date of its last commit (see CommitDates).
+//
+// The page is only updated if this date is at least a day after published.
+// Otherwise, published is returned.
+func UpdatedDate(fm FrontMatter, committed time.Time, published time.Time) time.Time {
+	updated := fm.Updated
+	if updated.IsZero() {
+		updated = committed
+	}
+	if updated.Before(published.AddDate(0, 0, 1)) {
+		return published
+	}
+	return updated
+}
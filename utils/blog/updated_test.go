@@ -0,0 +1,108 @@
+package blog_test
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Darkness4/blog/utils/blog"
+)
+
+func TestUpdatedDate(t *testing.T) {
+	published := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		title     string
+		updated   time.Time
+		committed time.Time
+		expected  time.Time
+	}{
+		{title: "Not updated", expected: published},
+		{title: "Front-matter", updated: published.AddDate(0, 1, 0), expected: published.AddDate(0, 1, 0)},
+		{title: "Commit", committed: published.AddDate(0, 2, 0), expected: published.AddDate(0, 2, 0)},
+		{
+			title:     "Front-matter over commit",
+			updated:   published.AddDate(0, 1, 0),
+			committed: published.AddDate(0, 2, 0),
+			expected:  published.AddDate(0, 1, 0),
+		},
+		{title: "Same day", committed: published.Add(12 * time.Hour), expected: published},
+		{title: "Before", updated: published.AddDate(0, 0, -3), expected: published},
+	}
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			got := blog.UpdatedDate(blog.FrontMatter{Updated: tt.updated}, tt.committed, published)
+			if !got.Equal(tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestCommitDates(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not available")
+	}
+	t.Chdir(t.TempDir())
+	git := func(date string, args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=a", "GIT_AUTHOR_EMAIL=a@example.com", "GIT_AUTHOR_DATE="+date,
+			"GIT_COMMITTER_NAME=a", "GIT_COMMITTER_EMAIL=a@example.com", "GIT_COMMITTER_DATE="+date,
+		)
+		out, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("git %v: %v", args, err)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	commit := func(date string, name string, content string) string {
+		t.Helper()
+		if err := os.WriteFile(name, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		git(date, "add", "-A")
+		git(date, "commit", "-q", "-m", name)
+		return git(date, "rev-parse", "HEAD")
+	}
+	git("", "init", "-q")
+	commit("2024-01-01T00:00:00Z", "page.md", "v1")
+	commit("2024-02-01T00:00:00Z", "page.md", "v2")
+	mechanical := commit("2024-03-01T00:00:00Z", "page.md", "v3")
+	commit("2024-04-01T00:00:00Z", blog.IgnoreRevsFile, "# Mechanical\n"+mechanical+"\n")
+
+	dates, err := blog.NewCommitDates()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, expected := range map[string]time.Time{
+		"page.md":    time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+		"missing.md": {},
+	} {
+		got, err := dates.Last(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !got.Equal(expected) {
+			t.Errorf("%s: expected %v, got %v", name, expected, got)
+		}
+	}
+
+	// The dates of a shallow clone are wrong.
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	git("", "clone", "-q", "--depth", "1", "file://"+dir, "shallow")
+	t.Chdir("shallow")
+	if _, err := blog.NewCommitDates(); err == nil {
+		t.Error("expected an error in a shallow repository")
+	}
+
+	var none *blog.CommitDates
+	if got, err := none.Last("page.md"); err != nil || !got.IsZero() {
+		t.Errorf("expected no date, got %v, %v", got, err)
+	}
+}
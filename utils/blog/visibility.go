@@ -0,0 +1,25 @@
+package blog
+
+import "time"
+
+// Visibility is the publication state of a page, read from its front-matter.
+//
+//	draft: true                     # Never published, only previewable.
+//	publishAt: 2024-01-11T08:00:00Z # Published automatically at this time.
+//	unlisted: true                  # Published, but hidden from listings.
+type Visibility struct {
+	Draft     bool
+	PublishAt time.Time
+	Unlisted  bool
+}
+
+// Published reports whether the page can be served publicly at t.
+func (v Visibility) Published(t time.Time) bool {
+	return !v.Draft && !t.Before(v.PublishAt)
+}
+
+// Listed reports whether the page appears in the index, the feeds, the sitemap
+// and the search at t.
+func (v Visibility) Listed(t time.Time) bool {
+	return v.Published(t) && !v.Unlisted
+}
@@ -0,0 +1,195 @@
+// Package i18n formats dates, durations and counts per language.
+package i18n
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Darkness4/blog/utils/math"
+)
+
+// Default is the language of the pages without a language suffix.
+const Default = "en"
+
+// Locale are the words of a language.
+type Locale struct {
+	Days   [7]string
+	Months [12]string
+	Hour   string
+	Hours  string
+	Min    string
+	Mins   string
+	// Word and CodeLine are the units of the statistics of a page.
+	Word      string
+	Words     string
+	CodeLine  string
+	CodeLines string
+	// Read follows the reading time of a page.
+	Read string
+	// Updated precedes the updated date of a page.
+	Updated string
+	// Viewed is the format of the views of a page, with the count and Time or
+	// Times.
+	Viewed string
+	Time   string
+	Times  string
+	// InSeries precedes the series of a page.
+	InSeries     string
+	PreviousPart string
+	NextPart     string
+	OldArticle   string
+	NewArticle   string
+	Related      string
+	// Archive is the title of the archive of every article.
+	Archive string
+}
+
+var locales = map[string]Locale{
+	"en": {
+		Days: [7]string{
+			"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday",
+		},
+		Months: [12]string{
+			"January", "February", "March", "April", "May", "June",
+			"July", "August", "September", "October", "November", "December",
+		},
+		Hour:  "hour",
+		Hours: "hours",
+		Min:   "min",
+		Mins:  "mins",
+
+		Word:      "word",
+		Words:     "words",
+		CodeLine:  "line of code",
+		CodeLines: "lines of code",
+		Read:      "read",
+
+		Updated: "Updated",
+		Viewed:  "Viewed %s %s",
+		Time:    "time",
+		Times:   "times",
+
+		InSeries:     "This article is part of the series",
+		PreviousPart: "Previous Part",
+		NextPart:     "Next Part",
+		OldArticle:   "Old Article",
+		NewArticle:   "New Article",
+		Related:      "Related articles",
+		Archive:      "Archive",
+	},
+	"fr": {
+		Days: [7]string{
+			"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi",
+		},
+		Months: [12]string{
+			"janvier", "février", "mars", "avril", "mai", "juin",
+			"juillet", "août", "septembre", "octobre", "novembre", "décembre",
+		},
+		Hour:  "heure",
+		Hours: "heures",
+		Min:   "min",
+		Mins:  "min",
+
+		Word:      "mot",
+		Words:     "mots",
+		CodeLine:  "ligne de code",
+		CodeLines: "lignes de code",
+		Read:      "de lecture",
+
+		Updated: "Mis à jour le",
+		Viewed:  "Vu %s %s",
+		Time:    "fois",
+		Times:   "fois",
+
+		InSeries:     "Cet article fait partie de la série",
+		PreviousPart: "Partie précédente",
+		NextPart:     "Partie suivante",
+		OldArticle:   "Article précédent",
+		NewArticle:   "Article suivant",
+		Related:      "Articles similaires",
+		Archive:      "Archives",
+	},
+}
+
+// Supported returns true if lang has a locale.
+func Supported(lang string) bool {
+	_, ok := locales[lang]
+	return ok
+}
+
+// Lookup returns the locale of lang, or the one of Default if lang is not
+// supported.
+func Lookup(lang string) Locale {
+	if l, ok := locales[lang]; ok {
+		return l
+	}
+	return locales[Default]
+}
+
+// FormatDate formats t with the layout of time.Format, with the day and month
+// names of lang.
+func FormatDate(lang string, layout string, t time.Time) string {
+	out := t.Format(layout)
+	if lang == Default {
+		return out
+	}
+	l := Lookup(lang)
+	out = strings.ReplaceAll(out, t.Weekday().String(), l.Days[t.Weekday()])
+	return strings.ReplaceAll(out, t.Month().String(), l.Months[t.Month()-1])
+}
+
+// FormatDuration formats d in hours and minutes in lang, e.g. "1 hour 5 mins".
+func FormatDuration(lang string, d time.Duration) string {
+	l := Lookup(lang)
+	h := d / time.Hour
+	d -= h * time.Hour
+	m := d / time.Minute
+
+	hourFormat := l.Hour
+	if h > 1 {
+		hourFormat = l.Hours
+	}
+	minuteFormat := l.Min
+	if m > 1 {
+		minuteFormat = l.Mins
+	}
+
+	res := ""
+	if h > 0 {
+		res += fmt.Sprintf("%d %s ", h, hourFormat)
+	}
+	if m > 0 {
+		res += fmt.Sprintf("%d %s", m, minuteFormat)
+	}
+	return res
+}
+
+// FormatStats formats the statistics of a page in lang, e.g. "120 words, 5
+// lines of code". The lines of code are omitted if there are none.
+func FormatStats(lang string, words int, codeLines int) string {
+	l := Lookup(lang)
+	wordFormat := l.Word
+	if words > 1 {
+		wordFormat = l.Words
+	}
+	res := fmt.Sprintf("%d %s", words, wordFormat)
+	if codeLines > 0 {
+		codeLineFormat := l.CodeLine
+		if codeLines > 1 {
+			codeLineFormat = l.CodeLines
+		}
+		res += fmt.Sprintf(", %d %s", codeLines, codeLineFormat)
+	}
+	return res
+}
+
+// FormatViews formats the views of a page in lang, e.g. "Viewed 1.2k times".
+func FormatViews(lang string, views int64) string {
+	l := Lookup(lang)
+	unit := l.Times
+	if views == 1 {
+		unit = l.Time
+	}
+	return fmt.Sprintf(l.Viewed, math.FormatNumber(float64(views)), unit)
+}
@@ -0,0 +1,25 @@
+package i18n_test
+
+import (
+	"testing"
+
+	"github.com/Darkness4/blog/utils/i18n"
+)
+
+func TestFormatViews(t *testing.T) {
+	tests := []struct {
+		lang     string
+		views    int64
+		expected string
+	}{
+		{lang: "en", views: 1, expected: "Viewed 1 time"},
+		{lang: "en", views: 1234, expected: "Viewed 1.2k times"},
+		{lang: "fr", views: 2, expected: "Vu 2 fois"},
+		{lang: "xx", views: 2, expected: "Viewed 2 times"},
+	}
+	for _, tt := range tests {
+		if got := i18n.FormatViews(tt.lang, tt.views); got != tt.expected {
+			t.Errorf("%s, %d: expected %q, got %q", tt.lang, tt.views, tt.expected, got)
+		}
+	}
+}
@@ -3,22 +3,38 @@
 package main
 
 import (
-	"bufio"
+	"bytes"
+	"context"
 	"embed"
+	"encoding/json"
 	"fmt"
+	"io"
+	"io/fs"
+	"maps"
 	"os"
+	"os/exec"
+	"os/signal"
 	"path/filepath"
+	"runtime"
+	"slices"
 	"strings"
+	"sync"
+	"syscall"
 	"text/template"
 	"time"
 
 	"github.com/Darkness4/blog/d2"
 	"github.com/Darkness4/blog/images"
 	"github.com/Darkness4/blog/markdown"
+	"github.com/Darkness4/blog/shortcode"
 	"github.com/Darkness4/blog/utils/blog"
+	"github.com/Darkness4/blog/utils/i18n"
 	"github.com/Darkness4/blog/utils/ptr"
 	"github.com/Darkness4/blog/utils/unique"
+	"github.com/Darkness4/blog/web/buildcache"
+	"github.com/Darkness4/blog/web/devserver"
 	"github.com/Darkness4/blog/web/index"
+	"github.com/Darkness4/blog/web/linkcheck"
 	chromahtml "github.com/alecthomas/chroma/v2/formatters/html"
 	mathjax "github.com/litao91/goldmark-mathjax"
 	"github.com/rs/zerolog/log"
@@ -26,6 +42,7 @@ import (
 	"github.com/yuin/goldmark"
 	highlighting "github.com/yuin/goldmark-highlighting/v2"
 	meta "github.com/yuin/goldmark-meta"
+	"github.com/yuin/goldmark/ast"
 	"github.com/yuin/goldmark/extension"
 	"github.com/yuin/goldmark/parser"
 	"github.com/yuin/goldmark/renderer"
@@ -39,72 +56,38 @@ import (
 
 var (
 	//go:embed pages/*
-	md embed.FS
+	embeddedPages embed.FS
 
-	//go:embed templates/markdown.tmpl templates/markdown-blog.tmpl
-	mdTmpl embed.FS
+	//go:embed templates/markdown.tmpl templates/markdown-blog.tmpl templates/series.tmpl
+	embeddedTemplates embed.FS
 )
 
-func sanitize(input string) string {
-	out := input
-	// Replace \" with " (this happens in script blocks)
-	out = strings.ReplaceAll(out, "\\\"", "\"")
-	out = strings.ReplaceAll(out, "\\&quot;", "\"")
-	// Replace {{% with [[ (safe {{)
-	out = strings.ReplaceAll(out, "{{%", "safe[[")
-	out = strings.ReplaceAll(out, "%}}", "safe]]")
-	// Replace {{ with &#123;&#123; (unsafe {{), this is because some "code" content is literally using {{.
-	out = strings.ReplaceAll(out, "{{", "&#123;&#123;")
-	// Replace safe [[ with {{
-	out = strings.ReplaceAll(out, "safe[[", "{{")
-	out = strings.ReplaceAll(out, "safe]]", "}}")
-	return out
-}
-
-func wrapperRenderer(w util.BufWriter, ctx highlighting.CodeBlockContext, entering bool) {
-	attrs := ctx.Attributes()
-	var title string
-	if attrs != nil {
-		attr, _ := attrs.GetString("title")
-		if attr != nil {
-			if titleAttr, ok := attr.([]uint8); ok {
-				title = string(titleAttr)
-			}
-		}
-	}
-
-	if entering {
-		w.WriteString("<div class=\"chroma code-container\">") // Open code-container
-		if title != "" {
-			w.WriteString("<div class=\"code-header\">")
-			w.WriteString(title)
-			w.WriteString("</div>")
-		}
-		w.WriteString("<div class=\"code-content\">") // Open code-block
-		// Copy code button
-		w.WriteString("<div class=\"code-btn-group\">")
-		w.WriteString(
-			`<button type="button" aria-label="Copy code to clipboard" title="Copy" class="copy-btn"
-			_="on click call copyCode(closest parent .code-content)">`,
-		)
-		w.WriteString("<span aria-hidden=\"true\" class=\"copy-btn-icons\">")
-		w.WriteString(
-			"<svg viewBox=\"0 0 24 24\" class=\"copy-btn-icon\"><path fill=\"currentColor\" d=\"M19,21H8V7H19M19,5H8A2,2 0 0,0 6,7V21A2,2 0 0,0 8,23H19A2,2 0 0,0 21,21V7A2,2 0 0,0 19,5M16,1H4A2,2 0 0,0 2,3V17H4V3H16V1Z\"></path></svg>",
-		)
-		w.WriteString(
-			"<svg viewBox=\"0 0 24 24\" class=\"copy-btn-success-icon\"><path fill=\"currentColor\" d=\"M21,7L9,19L3.5,13.5L4.91,12.09L9,16.17L19.59,5.59L21,7Z\"></path></svg>",
-		)
-		w.WriteString("</span>")
-		w.WriteString("</button>")
-		w.WriteString("</div>")
-	} else {
-		w.WriteString("</div>") // Close code-block
-		w.WriteString(`</div>`) // Close code-container
-	}
-}
-
-func processDirectory(fs embed.FS, dirPath string, filePaths chan<- string) error {
-	out, err := fs.ReadDir(dirPath)
+// md are the sources of the pages, and mdTmpl their templates. The dev server
+// reads them from the disk instead, to see their changes.
+var (
+	md     fs.FS = embeddedPages
+	mdTmpl fs.FS = embeddedTemplates
+)
+
+const (
+	// cacheDir is the directory of the persistent build cache.
+	cacheDir = ".cache/build"
+
+	codeStyle = "onedark"
+
+	defaultAuthor = "Marc Nguyen"
+)
+
+var d2RenderOptions = d2.RenderOptions{
+	ThemeID:     &d2themescatalog.NeutralDefault.ID,
+	DarkThemeID: &d2themescatalog.DarkMauve.ID,
+	Scale:       ptr.Ref(0.9),
+	Pad:         ptr.Ref(int64(25)),
+	Center:      ptr.Ref(true),
+}
+
+func processDirectory(fsys fs.FS, dirPath string, filePaths chan<- string) error {
+	out, err := fs.ReadDir(fsys, dirPath)
 	if err != nil {
 		return err
 	}
@@ -114,7 +97,7 @@ func processDirectory(fs embed.FS, dirPath string, filePaths chan<- string) erro
 
 		if file.IsDir() {
 			// If it's a directory, recursively process it
-			if err := processDirectory(fs, filePath, filePaths); err != nil {
+			if err := processDirectory(fsys, filePath, filePaths); err != nil {
 				return err
 			}
 		} else {
@@ -144,7 +127,29 @@ func filterBlogPages(input <-chan string) (blogPages <-chan string, rest <-chan
 			if strings.HasPrefix(filepath.Base(file), "-") {
 				continue
 			}
-			if strings.HasPrefix(file, "pages/blog") && filepath.Base(file) == "page.md" {
+			lang, ok := blog.PageLanguage(filepath.Base(file))
+			if !strings.HasPrefix(file, "pages/blog") || !ok {
+				r <- file
+			} else if !i18n.Supported(lang) {
+				reportError(fmt.Errorf("%s: unsupported language: %s", file, lang))
+			} else {
+				o <- file
+			}
+		}
+	}()
+	return o, r
+}
+
+// filterImages splits the images processed by images.Process from the other
+// files.
+func filterImages(input <-chan string) (imageFiles <-chan string, rest <-chan string) {
+	o := make(chan string, 100)
+	r := make(chan string, 100)
+	go func() {
+		defer close(o)
+		defer close(r)
+		for file := range input {
+			if images.IsImage(file) {
 				o <- file
 			} else {
 				r <- file
@@ -154,77 +159,571 @@ func filterBlogPages(input <-chan string) (blogPages <-chan string, rest <-chan
 	return o, r
 }
 
-func triple(input <-chan string) <-chan struct {
+// filterListedPages splits the blog pages between the ones that are part of the
+// navigation and the ones that are not published or listed at build time
+// (drafts, scheduled and unlisted pages).
+//
+// A scheduled page joins the navigation of its neighbours on the first build
+// after its publication.
+//
+// Pages with an invalid front-matter are reported and skipped.
+func filterListedPages(input <-chan string) (listed <-chan string, hidden <-chan string) {
+	now := time.Now()
+	o := make(chan string, 100)
+	h := make(chan string, 100)
+	go func() {
+		defer close(o)
+		defer close(h)
+		for file := range input {
+			content, err := fs.ReadFile(md, file)
+			if err != nil {
+				log.Fatal().Err(err).Msg("read file failure")
+			}
+			fm, err := blog.ParseFrontMatter(file, content)
+			if err != nil {
+				reportError(err)
+				continue
+			}
+			if fm.Listed(now) {
+				o <- file
+			} else {
+				h <- file
+			}
+		}
+	}()
+	return o, h
+}
+
+// splitLanguages splits the blog pages per language, so that each language has
+// its own navigation. The languages are in order of first appearance.
+func splitLanguages(input <-chan string) []<-chan string {
+	var langs []string
+	pages := make(map[string][]string)
+	for file := range input {
+		lang, _ := blog.PageLanguage(filepath.Base(file))
+		if _, ok := pages[lang]; !ok {
+			langs = append(langs, lang)
+		}
+		pages[lang] = append(pages[lang], file)
+	}
+	outputs := make([]<-chan string, 0, len(langs))
+	for _, lang := range langs {
+		output := make(chan string, len(pages[lang]))
+		for _, file := range pages[lang] {
+			output <- file
+		}
+		close(output)
+		outputs = append(outputs, output)
+	}
+	return outputs
+}
+
+// seriesPart is a page of a series.
+type seriesPart struct {
+	Title       string
+	Description string
+	Href        string
+	Order       int
+	Current     bool
+
+	file string
+}
+
+// seriesBox is a series, as listed on its pages and on its landing page.
+type seriesBox struct {
+	Name  string
+	Href  string
+	Lang  string
+	Parts []seriesPart
+}
+
+type seriesID struct {
+	lang string
+	name string
+}
+
+// seriesIndex are the series of the listed blog pages, per language.
+type seriesIndex map[seriesID]*seriesBox
+
+// collectSeries records the series of the pages, then sends the pages to the
+// output.
+//
+// The parts of a series sharing the same order, and the series sharing the same
+// landing page, are reported.
+func collectSeries(input <-chan string) (seriesIndex, <-chan string) {
+	series := make(seriesIndex)
+	var files []string
+	for file := range input {
+		files = append(files, file)
+		content, err := fs.ReadFile(md, file)
+		if err != nil {
+			log.Fatal().Err(err).Msg("read file failure")
+		}
+		fm, err := blog.ParseFrontMatter(file, content)
+		if err != nil || fm.Series == nil {
+			continue
+		}
+		if fm.Description == "" {
+			doc := goldmark.New(goldmark.WithExtensions(meta.Meta)).Parser().Parse(text.NewReader(content))
+			fm.Description = blog.Excerpt(doc, content)
+		}
+		lang, _ := blog.PageLanguage(filepath.Base(file))
+		id := seriesID{lang: lang, name: fm.Series.Name}
+		box, ok := series[id]
+		if !ok {
+			box = &seriesBox{
+				Name: fm.Series.Name,
+				Href: blog.LanguagePrefix(lang) + "/series/" + blog.Slug(fm.Series.Name),
+				Lang: lang,
+			}
+			series[id] = box
+		}
+		box.Parts = append(box.Parts, seriesPart{
+			Title:       fm.Title,
+			Description: fm.Description,
+			Href:        blog.Href(file),
+			Order:       fm.Series.Order,
+			file:        file,
+		})
+	}
+
+	hrefs := make(map[string]string)
+	for _, box := range series {
+		if name, ok := hrefs[box.Href]; ok {
+			reportError(fmt.Errorf("series %q and %q share the page %s", name, box.Name, box.Href))
+		}
+		hrefs[box.Href] = box.Name
+
+		slices.SortStableFunc(box.Parts, func(a, b seriesPart) int {
+			return a.Order - b.Order
+		})
+		for i := 1; i < len(box.Parts); i++ {
+			if box.Parts[i].Order == box.Parts[i-1].Order {
+				reportError(fmt.Errorf(
+					"%s: series %q: order %d is already used by %s",
+					box.Parts[i].file, box.Name, box.Parts[i].Order, box.Parts[i-1].file,
+				))
+			}
+		}
+	}
+
+	output := make(chan string, len(files))
+	for _, file := range files {
+		output <- file
+	}
+	close(output)
+	return series, output
+}
+
+// of returns the series of a page, with the page highlighted, and the previous
+// and next parts of the page. The series is nil if the page is not part of a
+// listed series.
+func (s seriesIndex) of(file string, fm blog.FrontMatter) (box *seriesBox, prev string, next string) {
+	if fm.Series == nil {
+		return nil, "", ""
+	}
+	lang, _ := blog.PageLanguage(filepath.Base(file))
+	found, ok := s[seriesID{lang: lang, name: fm.Series.Name}]
+	if !ok {
+		return nil, "", ""
+	}
+	box = &seriesBox{
+		Name:  found.Name,
+		Href:  found.Href,
+		Lang:  found.Lang,
+		Parts: slices.Clone(found.Parts),
+	}
+	for i := range box.Parts {
+		if box.Parts[i].file != file {
+			continue
+		}
+		box.Parts[i].Current = true
+		if i > 0 {
+			prev = box.Parts[i-1].Href
+		}
+		if i+1 < len(box.Parts) {
+			next = box.Parts[i+1].Href
+		}
+	}
+	return box, prev, next
+}
+
+// renderSeriesPages renders the landing pages of the series with out.
+func renderSeriesPages(series seriesIndex, out output) {
+	t := template.Must(template.ParseFS(mdTmpl, "templates/series.tmpl"))
+	for _, box := range series {
+		var buf bytes.Buffer
+		if err := t.Execute(&buf, box); err != nil {
+			log.Fatal().Err(err).Msg("generate file from template failure")
+		}
+		if err := out.WriteFile(filepath.Join("gen/pages", box.Href, "page.tmpl"), buf.Bytes()); err != nil {
+			log.Fatal().Err(err).Msg("write file failure")
+		}
+	}
+}
+
+// neighbours is a blog page with its previous and next pages.
+type neighbours struct {
 	prev string
 	curr string
 	next string
-} {
-	output := make(chan struct {
-		prev string
-		curr string
-		next string
-	}, 100)
+}
+
+func triple(input <-chan string) <-chan neighbours {
+	output := make(chan neighbours, 100)
 	go func() {
 		defer close(output)
 		var prev, curr string
 		for next := range input {
 			if curr != "" {
-				output <- struct {
-					prev string
-					curr string
-					next string
-				}{prev: prev, curr: curr, next: next}
+				output <- neighbours{prev: prev, curr: curr, next: next}
 			}
 			prev, curr = curr, next
 		}
 
 		if curr != "" {
-			output <- struct {
-				prev string
-				curr string
-				next string
-			}{prev: prev, curr: curr, next: ""}
+			output <- neighbours{prev: prev, curr: curr, next: ""}
 		}
 	}()
 	return output
 }
 
-func processPages() {
-	_ = os.RemoveAll("gen")
+// alone wraps pages without navigation.
+func alone(input <-chan string) <-chan neighbours {
+	output := make(chan neighbours, 100)
+	go func() {
+		defer close(output)
+		for curr := range input {
+			output <- neighbours{curr: curr}
+		}
+	}()
+	return output
+}
+
+// merge merges the channels into one.
+func merge[T any](inputs ...<-chan T) <-chan T {
+	output := make(chan T, 100)
+	var wg sync.WaitGroup
+	for _, input := range inputs {
+		wg.Go(func() {
+			for v := range input {
+				output <- v
+			}
+		})
+	}
+	go func() {
+		wg.Wait()
+		close(output)
+	}()
+	return output
+}
+
+// drain reads the whole input before sending it to the output, so that the
+// producer never blocks on it.
+func drain[T any](input <-chan T) <-chan T {
+	output := make(chan T, 100)
+	go func() {
+		defer close(output)
+		var vs []T
+		for v := range input {
+			vs = append(vs, v)
+		}
+		for _, v := range vs {
+			output <- v
+		}
+	}()
+	return output
+}
+
+// generatorKey identifies the generator in the cache keys: it is the hash of
+// the Go sources of the packages of the module it is built from (this file,
+// the renderers, the shortcodes...), so that a change of the rendering never
+// restores the pages rendered before it. The versions of the other modules
+// seed every key (see buildcache.NewKey), and the templates are in the keys
+// of their pages.
+var generatorKey = sync.OnceValue(func() string {
+	out, err := exec.Command(
+		"go", "list", "-tags", "build", "-deps",
+		"-f", `{{if not .Standard}}{{if or (not .Module) .Module.Main}}{{range .GoFiles}}{{$.Dir}}/{{.}}{{"\n"}}{{end}}{{end}}{{end}}`,
+		"build.go",
+	).Output()
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to list the sources of the generator")
+	}
+	k := buildcache.NewKey()
+	for name := range strings.Lines(string(out)) {
+		name = strings.TrimSpace(name)
+		b, err := os.ReadFile(name)
+		if err != nil {
+			log.Fatal().Err(err).Msg("read file failure")
+		}
+		k.String(filepath.Base(name)).Bytes(b)
+	}
+	return k.Sum()
+})
+
+// pageKey computes the cache key of a page from its source, its assets, its
+// template, the rendering options and extra inputs (e.g. prev and next links).
+func pageKey(page string, tmpl string, extra ...string) string {
+	opts, err := json.Marshal(d2RenderOptions)
+	if err != nil {
+		log.Fatal().Err(err).Msg("marshal options failure")
+	}
+	k := buildcache.NewKey().
+		String(generatorKey()).
+		String(codeStyle).
+		Bytes(opts)
+	if err := k.File(md, page); err != nil {
+		log.Fatal().Err(err).Str("page", page).Msg("cache key failure")
+	}
+	if err := k.Dir(md, filepath.Join(filepath.Dir(page), "page.assets")); err != nil {
+		log.Fatal().Err(err).Str("page", page).Msg("cache key failure")
+	}
+	if err := k.File(mdTmpl, tmpl); err != nil {
+		log.Fatal().Err(err).Str("page", page).Msg("cache key failure")
+	}
+	for _, e := range extra {
+		k.String(e)
+	}
+	// The post links of a translation depend on the translated posts.
+	if lang, _ := blog.PageLanguage(filepath.Base(page)); lang != i18n.Default {
+		for _, post := range translatedPosts(lang) {
+			k.String(post)
+		}
+	}
+	return k.Sum()
+}
+
+// translatedPosts returns the blog posts translated in lang.
+func translatedPosts(lang string) []string {
+	files, err := fs.Glob(md, filepath.Join("pages/blog", "*", "page."+lang+".md"))
+	if err != nil {
+		log.Fatal().Err(err).Msg("glob failure")
+	}
+	posts := make([]string, 0, len(files))
+	for _, f := range files {
+		posts = append(posts, filepath.Base(filepath.Dir(f)))
+	}
+	return posts
+}
+
+// isTranslated reports whether a blog post is translated in lang.
+func isTranslated(post string, lang string) bool {
+	_, err := fs.Stat(md, filepath.Join("pages/blog", post, "page."+lang+".md"))
+	return err == nil
+}
+
+// commitDates are the dates of the commits of the pages, the fallback of their
+// updated field.
+var commitDates = sync.OnceValue(func() *blog.CommitDates {
+	dates, err := blog.NewCommitDates()
+	if err != nil {
+		log.Warn().Err(err).Msg("the pages are only updated from their front-matter")
+	}
+	return dates
+})
+
+// output is where the generated files are written.
+type output interface {
+	WriteFile(name string, data []byte) error
+	// Remove removes a generated file or directory.
+	Remove(name string) error
+}
+
+// diskOutput writes the generated files on the disk.
+type diskOutput struct{}
+
+func (diskOutput) WriteFile(name string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(name, data, 0o644)
+}
+
+func (diskOutput) Remove(name string) error {
+	return os.RemoveAll(name)
+}
+
+// restore writes a cached entry into dir.
+func (wk *worker) restore(dir string, entry buildcache.Entry) {
+	for name, b := range entry {
+		if err := wk.out.WriteFile(filepath.Join(dir, name), b); err != nil {
+			log.Fatal().Err(err).Msg("write file failure")
+		}
+	}
+}
+
+// articleName is the name of the feed article in the cache entries of the blog
+// pages. It is written apart from the page (see articlePath).
+const articleName = "page.html"
+
+// articlePath returns the path of the feed article of the blog page at href.
+// The articles are generated out of gen/pages, so that they are never served
+// (see the FeedContentFunc of the web package).
+func articlePath(href string) string {
+	return filepath.Join("gen/articles", href+".html")
+}
 
-	// Markdown engine
+// restoreBlogPage writes a cached blog page into dir, and its feed article to
+// article.
+func (wk *worker) restoreBlogPage(dir string, article string, entry buildcache.Entry) {
+	files := maps.Clone(entry)
+	delete(files, articleName)
+	wk.restore(dir, files)
+	if b, ok := entry[articleName]; ok {
+		if err := wk.out.WriteFile(article, b); err != nil {
+			log.Fatal().Err(err).Msg("write file failure")
+		}
+	}
+}
+
+// href returns the URL path of a page, or "" if there is no page.
+func href(file string) string {
+	if file == "" {
+		return ""
+	}
+	return blog.Href(file)
+}
+
+// alternate is a translation of a page.
+type alternate struct {
+	Lang string
+	Href string
+}
+
+// alternates returns the translations of a page, including itself and the
+// default language first, or nil if the page is not translated.
+func alternates(file string) []alternate {
+	entries, err := fs.ReadDir(md, filepath.Dir(file))
+	if err != nil {
+		log.Fatal().Err(err).Msg("read dir failure")
+	}
+	var alts []alternate
+	for _, e := range entries {
+		lang, ok := blog.PageLanguage(e.Name())
+		if !ok || e.IsDir() {
+			continue
+		}
+		alt := alternate{
+			Lang: lang,
+			Href: blog.Href(filepath.Join(filepath.Dir(file), e.Name())),
+		}
+		// The page in the default language is the x-default.
+		if lang == i18n.Default {
+			alts = append([]alternate{alt}, alts...)
+		} else {
+			alts = append(alts, alt)
+		}
+	}
+	if len(alts) < 2 {
+		return nil
+	}
+	return alts
+}
+
+// newParserContext returns the parser context of a page.
+//
+// The relative images of a translation are served from the directory of the
+// page in the default language, and its post links target the posts in its
+// language when they are translated.
+func newParserContext(file string, fm blog.FrontMatter) parser.Context {
+	ctx := parser.NewContext()
+	ctx.Set(images.DirKey, filepath.Dir(file))
+	ctx.Set(d2.OptionsKey, fm.D2)
+	lang, _ := blog.PageLanguage(filepath.Base(file))
+	ctx.Set(markdown.LanguageKey, lang)
+	if lang != i18n.Default {
+		ctx.Set(images.BaseKey, strings.TrimPrefix(blog.Href(file), blog.LanguagePrefix(lang)))
+	}
+	return ctx
+}
+
+// worker renders pages with its own markdown engine and CSS collector.
+//
+// A goldmark instance and its CSS writer are not safe for concurrent use, so
+// each worker owns one.
+type worker struct {
+	cache     *buildcache.Cache
+	out       output
+	cssBuffer *unique.LineWriter
+	markdown  goldmark.Markdown
+	// keys, if set, skips the blog pages already rendered with the same key.
+	keys *renderedKeys
+}
+
+// renderedKeys are the cache keys the blog pages were last rendered with, by
+// source. It is safe for concurrent use.
+type renderedKeys struct {
+	mu   sync.Mutex
+	keys map[string]string
+}
+
+func newRenderedKeys() *renderedKeys {
+	return &renderedKeys{keys: make(map[string]string)}
+}
+
+// update records the key of a page, and returns false if the page was already
+// rendered with it.
+func (r *renderedKeys) update(page string, key string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.keys[page] == key {
+		return false
+	}
+	r.keys[page] = key
+	return true
+}
+
+// forget forgets the key of a page, so that it is rendered again.
+func (r *renderedKeys) forget(page string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.keys, page)
+}
+
+func newWorker(cache *buildcache.Cache, variants *images.Variants, out output) *worker {
 	cssBuffer := unique.NewLineWriter()
-	markdown := goldmark.New(
-		goldmark.WithParserOptions(parser.WithAutoHeadingID()),
+	wk := &worker{
+		cache:     cache,
+		out:       out,
+		cssBuffer: cssBuffer,
+	}
+	wk.markdown = goldmark.New(
+		goldmark.WithParserOptions(
+			parser.WithAutoHeadingID(),
+			parser.WithASTTransformers(
+				util.Prioritized(&markdown.PostLinkTransformer{Translated: isTranslated}, 0),
+			),
+		),
 		goldmark.WithRendererOptions(
 			goldmarkhtml.WithUnsafe(),
-			renderer.WithNodeRenderers(util.Prioritized(markdown.NewRenderer(), 1)),
+			renderer.WithNodeRenderers(
+				util.Prioritized(markdown.NewRenderer(), 1),
+				util.Prioritized(markdown.NewCodeBlockRenderer(
+					highlighting.WithStyle(codeStyle),
+					highlighting.WithCSSWriter(cssBuffer),
+					highlighting.WithFormatOptions(
+						chromahtml.WithLineNumbers(true),
+						chromahtml.WithClasses(true),
+					),
+				), 1),
+			),
 		),
 		images.NewReplacer(func(link string) string {
 			if filepath.IsAbs(link) || strings.HasPrefix(strings.ToLower(link), "http") {
 				return link
 			}
-			return filepath.Join("{{% $.Path %}}", link)
+			return filepath.Join(shortcode.Action("$.Path"), link)
 		}),
+		images.NewResponsive(md, variants),
 		goldmark.WithExtensions(
-			mathjax.MathJax,
+			markdown.Math,
 			&d2.Extender{
-				RenderOptions: d2.RenderOptions{
-					ThemeID: &d2themescatalog.DarkMauve.ID,
-					Scale:   ptr.Ref(0.9),
-					Pad:     ptr.Ref(int64(25)),
-					Center:  ptr.Ref(true),
+				RenderOptions: d2RenderOptions,
+				AssetURL: func(name string) string {
+					return filepath.Join(shortcode.Action("$.Path"), name)
 				},
 			},
-			highlighting.NewHighlighting(
-				highlighting.WithStyle("onedark"),
-				highlighting.WithCSSWriter(cssBuffer),
-				highlighting.WithFormatOptions(
-					chromahtml.WithLineNumbers(true),
-					chromahtml.WithClasses(true),
-				),
-				highlighting.WithWrapperRenderer(wrapperRenderer),
-			),
 			extension.GFM,
 			meta.Meta,
 			&anchor.Extender{
@@ -234,268 +733,827 @@ func processPages() {
 				},
 			},
 			&admonitions.Extender{},
+			shortcode.New(
+				shortcode.Shortcode{
+					Name: "toc",
+					Render: func(w util.BufWriter, source []byte, n shortcode.Node) error {
+						return wk.renderTOC(w, n.OwnerDocument(), source)
+					},
+				},
+				shortcode.Runtime,
+			),
 		),
 	)
+	return wk
+}
 
-	files := files()
-	blogPages, files := filterBlogPages(files)
+// renderTOC renders the table of contents of a document.
+func (wk *worker) renderTOC(w io.Writer, doc ast.Node, source []byte) error {
+	tree, err := toc.Inspect(doc, source, toc.Compact(true))
+	if err != nil {
+		return err
+	}
+	list := toc.RenderList(tree)
+	if list == nil {
+		return nil
+	}
+	return wk.markdown.Renderer().Render(w, source, list)
+}
+
+// renderBlogPage renders a blog page with its navigation and its series.
+func (wk *worker) renderBlogPage(file neighbours, series seriesIndex) {
+	content, err := fs.ReadFile(md, file.curr)
+	if err != nil {
+		log.Fatal().Err(err).Msg("read file failure")
+	}
+	lang, _ := blog.PageLanguage(filepath.Base(file.curr))
+	curr := filepath.Join("gen/pages", blog.Href(file.curr), "page")
+
+	fm, err := blog.ParseFrontMatter(file.curr, content)
+	if err != nil {
+		reportError(err)
+		return
+	}
+	box, prevPart, nextPart := series.of(file.curr, fm)
+	date, err := blog.ExtractDate(filepath.Base(filepath.Dir(file.curr)))
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to parse date failure")
+	}
+	committed, err := commitDates().Last(file.curr)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to read the commit date")
+	}
+	updated := blog.UpdatedDate(fm, committed, date)
 
-	// Process blogPages
-	for file := range triple(blogPages) {
-		curr := file.curr
-		content, err := md.ReadFile(curr)
+	alternates := alternates(file.curr)
+	extra := []string{file.prev, file.next, updated.Format(time.RFC3339)}
+	for _, a := range alternates {
+		extra = append(extra, a.Lang)
+	}
+	if box != nil {
+		for _, p := range box.Parts {
+			extra = append(extra, p.Title, p.Href)
+		}
+	}
+	key := pageKey(file.curr, "templates/markdown-blog.tmpl", extra...)
+	if wk.keys != nil && !wk.keys.update(file.curr, key) {
+		return
+	}
+	article := articlePath(blog.Href(file.curr))
+	if entry, ok := wk.cache.Load(key); ok {
+		wk.restoreBlogPage(filepath.Dir(curr), article, entry)
+		return
+	}
+
+	func() {
+		var buf bytes.Buffer
+		var sb strings.Builder
+
+		ctx := newParserContext(file.curr, fm)
+		doc := wk.markdown.Parser().Parse(text.NewReader(content), parser.WithContext(ctx))
+		if err := wk.markdown.Renderer().Render(&sb, content, doc); err != nil {
+			log.Fatal().Err(err).Str("path", file.curr).Msg("write file failure")
+		}
+		failed := reportPageErrors(file.curr, ctx, doc)
+		assets := diagramAssets(doc)
+		wk.restore(filepath.Dir(curr), assets)
+		var tocSB strings.Builder
+		if err := wk.renderTOC(&tocSB, doc, content); err != nil {
+			log.Fatal().Err(err).Msg("toc render failure")
+		}
+
+		if fm.Description == "" {
+			fm.Description = blog.Excerpt(doc, content)
+		}
+		var updatedDate string
+		if updated.After(date) {
+			updatedDate = i18n.FormatDate(lang, "Monday 02 January 2006", updated)
+		}
+		// The statistics are counted as in the index, before the code blocks
+		// are replaced by the extensions.
+		plain := goldmark.New(goldmark.WithExtensions(meta.Meta, mathjax.MathJax)).Parser().Parse(text.NewReader(content))
+		stats := blog.CountStats(plain, content)
+
+		t := template.Must(template.ParseFS(mdTmpl, "templates/markdown-blog.tmpl"))
+		if err := t.Execute(&buf, struct {
+			Title         string
+			Description   string
+			Style         string
+			Body          string
+			PublishedDate string
+			UpdatedDate   string
+			JSONLD        string
+			TOC           string
+			ReadingTime   string
+			Stats         string
+			Authors       string
+			Canonical     string
+			Lang          string
+			Locale        i18n.Locale
+			Alternates    []alternate
+			Series        *seriesBox
+			Curr          string
+			Prev          string
+			Next          string
+			PrevPart      string
+			NextPart      string
+		}{
+			Title:         fm.Title,
+			Description:   fm.Description,
+			Authors:       authors(fm),
+			Canonical:     fm.Canonical,
+			Style:         wk.cssBuffer.String(),
+			Body:          shortcode.Escape(sb.String()),
+			TOC:           shortcode.Escape(tocSB.String()),
+			ReadingTime:   i18n.FormatDuration(lang, stats.ReadingTime()),
+			Stats:         i18n.FormatStats(lang, stats.Words, stats.CodeLines),
+			PublishedDate: i18n.FormatDate(lang, "Monday 02 January 2006", date),
+			UpdatedDate:   updatedDate,
+			JSONLD:        shortcode.Escape(articleJSONLD(fm, lang, date, updated)),
+			Lang:          lang,
+			Locale:        i18n.Lookup(lang),
+			Alternates:    alternates,
+			Series:        box,
+
+			Curr:     href(file.curr),
+			Prev:     href(file.prev),
+			Next:     href(file.next),
+			PrevPart: prevPart,
+			NextPart: nextPart,
+		}); err != nil {
+			log.Fatal().Err(err).Msg("generate file from template failure")
+		}
+		wk.cssBuffer.Reset()
+		if err := wk.out.WriteFile(curr+".tmpl", buf.Bytes()); err != nil {
+			log.Fatal().Err(err).Msg("write file failure")
+		}
+		// The article alone is the content of the feeds.
+		articleHTML, err := renderArticle(sb.String(), blog.Href(file.curr))
 		if err != nil {
-			log.Fatal().Err(err).Msg("read file failure")
+			log.Fatal().Err(err).Str("path", file.curr).Msg("article render failure")
+		}
+		if err := wk.out.WriteFile(article, articleHTML); err != nil {
+			log.Fatal().Err(err).Msg("write file failure")
 		}
-		ext := filepath.Ext(curr)
-		curr = filepath.Join("gen", strings.TrimSuffix(curr, ext))
 
-		if err := os.MkdirAll(filepath.Dir(curr), 0o755); err != nil {
-			log.Fatal().Err(err).Msg("mkdir failure")
+		if failed {
+			// The errors must be reported again by the next build.
+			if wk.keys != nil {
+				wk.keys.forget(file.curr)
+			}
+			return
 		}
+		assets[filepath.Base(curr)+".tmpl"] = buf.Bytes()
+		assets[articleName] = articleHTML
+		if err := wk.cache.Save(key, assets); err != nil {
+			log.Err(err).Msg("cache failure")
+		}
+	}()
+}
+
+// renderFile renders a markdown page or copies an asset.
+func (wk *worker) renderFile(file string) {
+	content, err := fs.ReadFile(md, file)
+	if err != nil {
+		log.Fatal().Err(err).Msg("read file failure")
+	}
+	ext := filepath.Ext(file)
+	file = filepath.Join("gen", strings.TrimSuffix(file, ext))
+
+	func() {
+		if ext == ".md" {
+			src := filepath.Join("pages", strings.TrimPrefix(file, "gen/pages")) + ext
+			key := pageKey(src, "templates/markdown.tmpl")
+			if entry, ok := wk.cache.Load(key); ok {
+				wk.restore(filepath.Dir(file), entry)
+				return
+			}
 
-		func() {
-			w, err := os.Create(curr + ".tmpl")
+			var buf bytes.Buffer
+			fm, err := blog.ParseFrontMatter(src, content)
 			if err != nil {
-				log.Fatal().Err(err).Msg("create file failure")
+				reportError(err)
+				return
 			}
-			defer w.Close()
 
 			var sb strings.Builder
 
-			ctx := parser.NewContext()
-			doc := markdown.Parser().Parse(text.NewReader(content), parser.WithContext(ctx))
-			if err := markdown.Renderer().Render(&sb, content, doc); err != nil {
-				log.Fatal().Err(err).Msg("write file failure")
-			}
-			tree, err := toc.Inspect(doc, content, toc.Compact(true))
-			if err != nil {
-				log.Fatal().Err(err).Msg("toc failure")
+			ctx := newParserContext(src, fm)
+			doc := wk.markdown.Parser().Parse(text.NewReader(content), parser.WithContext(ctx))
+			if err := wk.markdown.Renderer().Render(&sb, content, doc); err != nil {
+				log.Fatal().Err(err).Str("path", src).Msg("write file failure")
 			}
+			failed := reportPageErrors(src, ctx, doc)
+			assets := diagramAssets(doc)
+			wk.restore(filepath.Dir(file), assets)
 			var tocSB strings.Builder
-			list := toc.RenderList(tree)
-			if list != nil {
-				if err := markdown.Renderer().Render(&tocSB, content, list); err != nil {
-					log.Fatal().Err(err).Msg("toc render failure")
-				}
+			if err := wk.renderTOC(&tocSB, doc, content); err != nil {
+				log.Fatal().Err(err).Msg("toc render failure")
 			}
 
-			out := sanitize(sb.String())
-
-			metaData := meta.Get(ctx)
-			date, err := blog.ExtractDate(filepath.Base(filepath.Dir(curr)))
-			if err != nil {
-				log.Fatal().Err(err).Msg("failed to parse date failure")
+			if fm.Description == "" {
+				fm.Description = blog.Excerpt(doc, content)
 			}
-			readingTime := computeReadingTime(string(content))
 
-			// Compile time variable
-			var bodySB strings.Builder
-			tBody, err := template.New("body").Parse(out)
-			if err != nil {
-				fmt.Fprint(w, out)
-				log.Fatal().
-					Err(err).
-					Str("path", curr+".tmpl").
-					Msg("body template failure, see file")
-			}
-			if err := tBody.Execute(&bodySB, struct {
-				TOC  string
-				Path string
-			}{
-				TOC:  tocSB.String(),
-				Path: "{{ $.Path }}", // Pass variable to runtime
-			}); err != nil {
-				log.Fatal().Err(err).Msg("body template failure")
-			}
-
-			t := template.Must(template.ParseFS(mdTmpl, "templates/markdown-blog.tmpl"))
-			if err := t.Execute(w, struct {
-				Title         string
-				Description   string
-				Style         string
-				Body          string
-				PublishedDate string
-				TOC           string
-				ReadingTime   string
-				Curr          string
-				Prev          string
-				Next          string
+			t := template.Must(template.ParseFS(mdTmpl, "templates/markdown.tmpl"))
+			if err := t.Execute(&buf, struct {
+				Title       string
+				Description string
+				Style       string
+				Body        string
+				TOC         string
+				Curr        string
 			}{
-				Title:         fmt.Sprintf("%v", metaData["title"]),
-				Description:   fmt.Sprintf("%v", metaData["description"]),
-				Style:         cssBuffer.String(),
-				Body:          bodySB.String(),
-				TOC:           tocSB.String(),
-				ReadingTime:   readingTime,
-				PublishedDate: date.Format("Monday 02 January 2006"),
-
-				Curr: strings.TrimSuffix(strings.TrimPrefix(file.curr, "pages"), "/page.md"),
-				Prev: strings.TrimSuffix(strings.TrimPrefix(file.prev, "pages"), "/page.md"),
-				Next: strings.TrimSuffix(strings.TrimPrefix(file.next, "pages"), "/page.md"),
+				Title:       fm.Title,
+				Description: fm.Description,
+				Style:       wk.cssBuffer.String(),
+				Body:        shortcode.Escape(sb.String()),
+				TOC:         shortcode.Escape(tocSB.String()),
+				Curr:        strings.TrimSuffix(strings.TrimPrefix(file, "gen/pages"), "/page.md"),
 			}); err != nil {
 				log.Fatal().Err(err).Msg("generate file from template failure")
 			}
-			cssBuffer.Reset()
-		}()
-	}
+			wk.cssBuffer.Reset()
+			if err := wk.out.WriteFile(file+".tmpl", buf.Bytes()); err != nil {
+				log.Fatal().Err(err).Msg("write file failure")
+			}
 
-	for file := range files {
-		content, err := md.ReadFile(file)
-		if err != nil {
-			log.Fatal().Err(err).Msg("read file failure")
+			if failed {
+				// The errors must be reported again by the next build.
+				return
+			}
+			assets[filepath.Base(file)+".tmpl"] = buf.Bytes()
+			if err := wk.cache.Save(key, assets); err != nil {
+				log.Err(err).Msg("cache failure")
+			}
+		} else {
+			if err := wk.out.WriteFile(file+ext, content); err != nil {
+				log.Fatal().Err(err).Msg("write file failure")
+			}
 		}
-		ext := filepath.Ext(file)
-		file = filepath.Join("gen", strings.TrimSuffix(file, ext))
+	}()
+}
 
-		if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
-			log.Fatal().Err(err).Msg("mkdir failure")
+// processImage strips the metadata of an image, generates its variants and
+// records them.
+func (wk *worker) processImage(file string, variants *images.Variants) {
+	content, err := fs.ReadFile(md, file)
+	if err != nil {
+		log.Fatal().Err(err).Msg("read file failure")
+	}
+	dir := filepath.Join("gen", filepath.Dir(file))
+
+	key := buildcache.NewKey().
+		String(generatorKey()).
+		String(file).
+		Bytes(content).
+		Sum()
+	if entry, ok := wk.cache.Load(key); ok {
+		wk.restore(dir, entry)
+		variants.Add(file, entry)
+		return
+	}
+
+	files, err := images.Process(file, content)
+	if err != nil {
+		reportError(err)
+		return
+	}
+	wk.restore(dir, files)
+	variants.Add(file, files)
+	if err := wk.cache.Save(key, files); err != nil {
+		log.Err(err).Msg("cache failure")
+	}
+}
+
+// processPages renders the pages with out and returns their series and the
+// variants of their images.
+//
+// If keys is set, the keys the blog pages are rendered with are recorded in it.
+func processPages(keys *renderedKeys, out output) (seriesIndex, *images.Variants) {
+	cache, err := buildcache.Open(cacheDir)
+	if err != nil {
+		log.Fatal().Err(err).Msg("open cache failure")
+	}
+	defer func() {
+		if err := cache.Prune(); err != nil {
+			log.Err(err).Msg("prune cache failure")
 		}
+	}()
 
-		func() {
-			if ext == ".md" {
-				w, err := os.Create(file + ".tmpl")
-				if err != nil {
-					log.Fatal().Err(err).Msg("create file failure")
-				}
-				defer w.Close()
+	files := files()
+	blogPages, files := filterBlogPages(files)
+	imageFiles, files := filterImages(files)
+	blogPages, files = drain(blogPages), drain(files)
 
-				var sb strings.Builder
+	// The pages list the variants of their images, so the images are
+	// processed first.
+	variants := images.NewVariants()
+	var wg sync.WaitGroup
+	for range runtime.GOMAXPROCS(0) {
+		wk := newWorker(cache, variants, out)
+		wg.Go(func() {
+			for file := range imageFiles {
+				wk.processImage(file, variants)
+			}
+		})
+	}
+	wg.Wait()
 
-				ctx := parser.NewContext()
-				doc := markdown.Parser().Parse(text.NewReader(content), parser.WithContext(ctx))
-				if err := markdown.Renderer().Render(&sb, content, doc); err != nil {
-					log.Fatal().Err(err).Msg("write file failure")
-				}
-				tree, err := toc.Inspect(doc, content, toc.Compact(true))
-				if err != nil {
-					log.Fatal().Err(err).Msg("toc failure")
-				}
-				var tocSB strings.Builder
-				list := toc.RenderList(tree)
-				if list != nil {
-					if err := markdown.Renderer().Render(&tocSB, content, list); err != nil {
-						log.Fatal().Err(err).Msg("toc render failure")
-					}
-				}
+	listedPages, hiddenPages := filterListedPages(blogPages)
+	hiddenPages = drain(hiddenPages)
+	series, listedPages := collectSeries(listedPages)
+	renderSeriesPages(series, out)
+	navigations := []<-chan neighbours{alone(hiddenPages)}
+	for _, listed := range splitLanguages(listedPages) {
+		navigations = append(navigations, triple(listed))
+	}
+	pages := merge(navigations...)
 
-				out := sanitize(sb.String())
+	// The navigation is computed by triple in filesystem order, so the pages
+	// can be rendered in any order.
+	for range runtime.GOMAXPROCS(0) {
+		wk := newWorker(cache, variants, out)
+		wk.keys = keys
+		wg.Go(func() {
+			for file := range pages {
+				wk.renderBlogPage(file, series)
+			}
+		})
+	}
+	// The other files are rendered at the same time.
+	wg.Go(func() {
+		wk := newWorker(cache, variants, out)
+		for file := range files {
+			wk.renderFile(file)
+		}
+	})
+	wg.Wait()
+	return series, variants
+}
 
-				metaData := meta.Get(ctx)
+// routes are the routes served by the server besides the pages and the static
+// files.
+var routes = []string{
+	"/search",
+	"/health",
+	"/rss",
+	"/atom",
+	"/json",
+	"/sitemap.xml",
+	"/robots.txt",
+}
 
-				// Compile time variable
-				var bodySB strings.Builder
-				tBody, err := template.New("body").Parse(out)
-				if err != nil {
-					fmt.Fprint(w, out)
-					log.Fatal().
-						Err(err).
-						Str("path", file+".tmpl").
-						Msg("body template failure, see file")
-				}
-				if err := tBody.Execute(&bodySB, struct {
-					TOC  string
-					Path string
-				}{
-					TOC:  tocSB.String(),
-					Path: "{{ $.Path }}", // Pass variable to runtime
-				}); err != nil {
-					log.Fatal().Err(err).Msg("body template failure")
-				}
+// checkLinks reports the internal links of the pages that do not resolve to a
+// page, an anchor, an asset or a route.
+//
+// The unpublished pages (drafts and scheduled pages) and their assets are not
+// served, so they are only the targets of the links of the other unpublished
+// pages, which are previewed. The tag and archive pages only exist for the
+// listed pages.
+func checkLinks(series seriesIndex) {
+	now := time.Now()
+	// public is the site served at build time, and all is the site previewed.
+	public, all := linkcheck.NewSite(), linkcheck.NewSite()
+	addPage := func(href string, anchors []string, served bool) {
+		all.AddPage(href, anchors)
+		if served {
+			public.AddPage(href, anchors)
+		}
+	}
+	addFile := func(href string, served bool) {
+		all.AddFile(href)
+		if served {
+			public.AddFile(href)
+		}
+	}
 
-				t := template.Must(template.ParseFS(mdTmpl, "templates/markdown.tmpl"))
-				if err := t.Execute(w, struct {
-					Title       string
-					Description string
-					Style       string
-					Body        string
-					TOC         string
-					Curr        string
-				}{
-					Title:       fmt.Sprintf("%v", metaData["title"]),
-					Description: fmt.Sprintf("%v", metaData["description"]),
-					Style:       cssBuffer.String(),
-					Body:        bodySB.String(),
-					TOC:         tocSB.String(),
-					Curr:        strings.TrimSuffix(strings.TrimPrefix(file, "gen/pages"), "/page.md"),
-				}); err != nil {
-					log.Fatal().Err(err).Msg("generate file from template failure")
-				}
-				cssBuffer.Reset()
-			} else {
-				w, err := os.Create(file + ext)
-				if err != nil {
-					log.Fatal().Err(err).Msg("create file failure")
-				}
-				defer w.Close()
+	for _, route := range routes {
+		addFile(route, true)
+	}
+	for _, box := range series {
+		addPage(box.Href, nil, true)
+	}
+	if err := fs.WalkDir(os.DirFS("."), "static", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		addFile("/"+path, true)
+		return nil
+	}); err != nil {
+		log.Fatal().Err(err).Msg("walk static failure")
+	}
 
-				if _, err := w.Write(content); err != nil {
-					log.Fatal().Err(err).Msg("write file failure")
+	type page struct {
+		path      string
+		href      string
+		links     []linkcheck.Link
+		published bool
+	}
+	var pages []page
+	langs := make(map[string]bool)
+	// tags and archives are the tag and archive pages, and whether a listed
+	// page is on them.
+	tags := make(map[string]bool)
+	archives := make(map[string]bool)
+	wk := newWorker(nil, nil, diskOutput{})
+	if err := fs.WalkDir(md, "pages", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		href := filepath.Join("/", strings.TrimPrefix(filepath.Dir(path), "pages"))
+		_, isPage := blog.PageLanguage(filepath.Base(path))
+		switch name := filepath.Base(path); {
+		case strings.HasPrefix(name, "-"):
+		case name == "page.tmpl":
+			addPage(href, nil, true)
+		case isPage:
+			lang, _ := blog.PageLanguage(name)
+			langs[lang] = true
+			href = blog.Href(path)
+			content, err := fs.ReadFile(md, path)
+			if err != nil {
+				return err
+			}
+			// The front-matter errors are reported while rendering.
+			fm, _ := blog.ParseFrontMatter(path, content)
+			isListed := fm.Listed(now)
+			for _, tag := range fm.Tags {
+				tag = blog.LanguagePrefix(lang) + "/tags/" + blog.Slug(tag)
+				tags[tag] = tags[tag] || isListed
+			}
+			if date, err := blog.ExtractDate(filepath.Base(filepath.Dir(path))); err == nil {
+				archive := blog.LanguagePrefix(lang) + "/archive"
+				for _, p := range []string{archive + date.Format("/2006"), archive + date.Format("/2006/01")} {
+					archives[p] = archives[p] || isListed
 				}
 			}
-		}()
+			doc := wk.markdown.Parser().Parse(text.NewReader(content), parser.WithContext(newParserContext(path, fm)))
+			anchors := []string{}
+			for _, h := range index.ExtractHeaders(doc, content) {
+				anchors = append(anchors, h.Anchor)
+			}
+			addPage(href, anchors, fm.Published(now))
+			pages = append(pages, page{
+				path:      path,
+				href:      href,
+				links:     linkcheck.Links(doc, content),
+				published: fm.Published(now),
+			})
+		case filepath.Ext(name) != ".md":
+			addFile("/"+strings.TrimPrefix(path, "pages/"), assetServed(path, now))
+		}
+		return nil
+	}); err != nil {
+		log.Fatal().Err(err).Msg("walk pages failure")
+	}
+
+	// The home page, the tag pages, the archive and the feeds are localized.
+	for lang := range langs {
+		prefix := blog.LanguagePrefix(lang)
+		if prefix != "" {
+			addPage(prefix, nil, true)
+			for _, feed := range []string{"/rss", "/atom", "/json"} {
+				addFile(prefix+feed, true)
+			}
+		}
+		addPage(prefix+"/tags", nil, true)
+		addPage(prefix+"/archive", nil, true)
+	}
+	for tag, served := range tags {
+		addPage(tag, nil, served)
+		for _, feed := range []string{"/rss", "/atom", "/json"} {
+			addFile(tag+feed, served)
+		}
+	}
+	for archive, served := range archives {
+		addPage(archive, nil, served)
+	}
+
+	for _, p := range pages {
+		site := all
+		if p.published {
+			site = public
+		}
+		if err := site.Check(p.path, p.href, p.links); err != nil {
+			reportError(err)
+		}
 	}
 }
 
-func countWords(line string) uint64 {
-	scanner := bufio.NewScanner(strings.NewReader(line))
-	scanner.Split(bufio.ScanWords)
-	count := uint64(0)
-	for scanner.Scan() {
-		count++
+// assetServed reports whether the asset at path is served at now. The assets
+// of a blog post are served with its page in the default language.
+func assetServed(path string, now time.Time) bool {
+	rel, ok := strings.CutPrefix(path, "pages/blog/")
+	if !ok {
+		return true
 	}
-	return count
+	post, _, _ := strings.Cut(rel, "/")
+	content, err := fs.ReadFile(md, filepath.Join("pages/blog", post, "page.md"))
+	if err != nil {
+		return false
+	}
+	fm, _ := blog.ParseFrontMatter(path, content)
+	return fm.Published(now)
+}
+
+// buildErrors are the errors reported while processing the pages. The build
+// goes on so that every error is reported at once, and fails at the end.
+var buildErrors struct {
+	sync.Mutex
+	errs []error
+}
+
+func reportError(err error) {
+	buildErrors.Lock()
+	defer buildErrors.Unlock()
+	buildErrors.errs = append(buildErrors.errs, err)
 }
 
-func countWordsInText(text string) (uint64, error) {
-	scanner := bufio.NewScanner(strings.NewReader(text))
-	words := uint64(0)
-	for scanner.Scan() {
-		line := scanner.Text()
-		// Count words in the line
-		words += countWords(line)
+// printErrors prints the errors reported so far and forgets them. It returns
+// their number.
+func printErrors() int {
+	buildErrors.Lock()
+	defer buildErrors.Unlock()
+	for _, err := range buildErrors.errs {
+		fmt.Fprintln(os.Stderr, err)
 	}
+	n := len(buildErrors.errs)
+	buildErrors.errs = nil
+	return n
+}
 
-	if err := scanner.Err(); err != nil {
-		return 0, err
+// diagramAssets returns the diagrams of a rendered page, to be written next
+// to it.
+func diagramAssets(doc ast.Node) buildcache.Entry {
+	assets := buildcache.Entry{}
+	for _, a := range d2.Assets(doc) {
+		assets[a.Name] = a.Data
 	}
+	return assets
+}
 
-	return words, nil
+// reportPageErrors reports the errors found while parsing and rendering the
+// page at path, and returns whether there were any.
+func reportPageErrors(path string, pc parser.Context, doc ast.Node) (failed bool) {
+	for _, err := range shortcode.Errors(pc) {
+		err.Path = path
+		reportError(err)
+		failed = true
+	}
+	for _, err := range markdown.MathErrors(pc) {
+		err.Path = path
+		reportError(err)
+		failed = true
+	}
+	for _, err := range d2.Errors(doc) {
+		err.Path = path
+		reportError(err)
+		failed = true
+	}
+	return failed
 }
 
-func computeReadingTime(text string) string {
-	words, err := countWordsInText(text)
+// authors returns the authors of a page, for the "author" meta tag.
+func authors(fm blog.FrontMatter) string {
+	if len(fm.Authors) == 0 {
+		return defaultAuthor
+	}
+	return strings.Join(fm.Authors, ", ")
+}
+
+// renderArticle executes the runtime actions of a rendered article as the
+// page render does, with the runtime data of the page at href.
+func renderArticle(rendered string, href string) ([]byte, error) {
+	t, err := template.New("article").Parse(shortcode.Escape(rendered))
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, struct{ Path string }{Path: href}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// articleJSONLD returns the schema.org metadata of a blog page, in JSON-LD.
+func articleJSONLD(fm blog.FrontMatter, lang string, published, updated time.Time) string {
+	type person struct {
+		Type string `json:"@type"`
+		Name string `json:"name"`
+	}
+	names := fm.Authors
+	if len(names) == 0 {
+		names = []string{defaultAuthor}
+	}
+	people := make([]person, 0, len(names))
+	for _, name := range names {
+		people = append(people, person{Type: "Person", Name: name})
+	}
+	b, err := json.Marshal(struct {
+		Context       string   `json:"@context"`
+		Type          string   `json:"@type"`
+		Headline      string   `json:"headline"`
+		Description   string   `json:"description"`
+		Keywords      []string `json:"keywords,omitempty"`
+		InLanguage    string   `json:"inLanguage"`
+		DatePublished string   `json:"datePublished"`
+		DateModified  string   `json:"dateModified"`
+		Author        []person `json:"author"`
+	}{
+		Context:       "https://schema.org",
+		Type:          "BlogPosting",
+		Headline:      fm.Title,
+		Description:   fm.Description,
+		Keywords:      fm.Tags,
+		InLanguage:    lang,
+		DatePublished: published.Format(time.RFC3339),
+		DateModified:  updated.Format(time.RFC3339),
+		Author:        people,
+	})
 	if err != nil {
-		log.Fatal().Err(err).Msg("count words failure")
+		log.Fatal().Err(err).Msg("json-ld failure")
+	}
+	return string(b)
+}
+
+// rebuild renders the changed sources of the pages with out, removes the
+// generated files of the removed sources, and returns the series of the pages.
+//
+// The navigation of the blog pages is computed again from the front-matters,
+// and the blog pages whose cache key changed since they were last rendered
+// (recorded in keys) are rendered: the changed pages, but also their
+// neighbours, the other parts of their series, their translations and the
+// pages of the changed images. The series pages and the index are generated
+// again if a blog page changed, and the series of prev which are gone are
+// removed. The other pages are rendered again if a template changed.
+func rebuild(
+	cache *buildcache.Cache,
+	variants *images.Variants,
+	keys *renderedKeys,
+	out output,
+	prev seriesIndex,
+	changed []string,
+	removed []string,
+) seriesIndex {
+	wk := newWorker(cache, variants, out)
+	wk.keys = keys
+	blogChanged, tmplChanged := false, false
+	for _, file := range changed {
+		_, isPage := blog.PageLanguage(filepath.Base(file))
+		switch {
+		case strings.HasPrefix(file, "templates/"):
+			tmplChanged = true
+		case strings.HasPrefix(filepath.Base(file), "-"):
+		case images.IsImage(file):
+			wk.processImage(file, variants)
+		case strings.HasPrefix(file, "pages/blog") && isPage:
+			blogChanged = true
+		default:
+			wk.renderFile(file)
+		}
+	}
+	for _, file := range removed {
+		_, isPage := blog.PageLanguage(filepath.Base(file))
+		gen := filepath.Join("gen", file)
+		switch {
+		case strings.HasPrefix(file, "templates/"):
+			tmplChanged = true
+		case strings.HasPrefix(filepath.Base(file), "-"):
+		case strings.HasPrefix(file, "pages/blog") && isPage:
+			// The assets of the post stay, as its translations use them.
+			blogChanged = true
+			keys.forget(file)
+			wk.remove(filepath.Join("gen/pages", blog.Href(file), "page.tmpl"), articlePath(blog.Href(file)))
+		case filepath.Ext(file) == ".md":
+			wk.remove(strings.TrimSuffix(gen, ".md") + ".tmpl")
+		case images.IsImage(file):
+			wk.remove(gen)
+			for _, w := range variants.Get(file) {
+				wk.remove(filepath.Join(filepath.Dir(gen), images.Variant(filepath.Base(file), w)))
+			}
+			// The variants are forgotten.
+			variants.Add(file, nil)
+		default:
+			wk.remove(gen)
+		}
+	}
+
+	blogPages, rest := filterBlogPages(files())
+	rest = drain(rest)
+	listedPages, hiddenPages := filterListedPages(blogPages)
+	hiddenPages = drain(hiddenPages)
+	series, listedPages := collectSeries(listedPages)
+	navigations := []<-chan neighbours{alone(hiddenPages)}
+	for _, listed := range splitLanguages(listedPages) {
+		navigations = append(navigations, triple(listed))
+	}
+	for file := range merge(navigations...) {
+		wk.renderBlogPage(file, series)
+	}
+	for file := range rest {
+		if tmplChanged && filepath.Ext(file) == ".md" {
+			wk.renderFile(file)
+		}
 	}
-	minutes := words / 75 // Reading rate for technical articles
+	if blogChanged || tmplChanged {
+		renderSeriesPages(series, out)
+		for id, box := range prev {
+			if _, ok := series[id]; !ok {
+				wk.remove(filepath.Join("gen/pages", box.Href, "page.tmpl"))
+			}
+		}
+	}
+	if blogChanged {
+		writeIndex(out)
+	}
+	return series
+}
 
-	d := time.Duration(minutes) * time.Minute
-	h := d / time.Hour
-	d -= h * time.Hour
-	m := d / time.Minute
+// remove removes generated files.
+func (wk *worker) remove(names ...string) {
+	for _, name := range names {
+		if err := wk.out.Remove(name); err != nil {
+			log.Fatal().Err(err).Msg("remove file failure")
+		}
+	}
+}
 
-	hourFormat := "hour"
-	if h > 1 {
-		hourFormat = "hours"
+// writeIndex writes the entries of the index for the dev server, which loads
+// them instead of its compiled index (see devserver.IndexName).
+func writeIndex(out output) {
+	b, err := index.MarshalEntries()
+	if err != nil {
+		log.Fatal().Err(err).Msg("index failure")
 	}
-	minuteFormat := "min"
-	if m > 1 {
-		minuteFormat = "mins"
+	if err := out.WriteFile(devserver.IndexName, b); err != nil {
+		log.Fatal().Err(err).Msg("write file failure")
 	}
+}
 
-	res := ""
-	if h > 0 {
-		res += fmt.Sprintf("%d %s ", h, hourFormat)
+// dev builds the pages, then rebuilds the pages whose sources or templates
+// changed on the disk until it is interrupted.
+//
+// Nothing is written in the working tree: the generated files and the index
+// are streamed to the standard output for the dev server (see the dev command
+// of the server), and hide the ones of the last build.
+func dev() {
+	md = os.DirFS(".")
+	mdTmpl = md
+	stream := devserver.NewStream(os.Stdout)
+	if err := stream.Remove("gen"); err != nil {
+		log.Fatal().Err(err).Msg("stream failure")
 	}
-	if m > 0 {
-		res += fmt.Sprintf("%d %s", m, minuteFormat)
+
+	start := time.Now()
+	keys := newRenderedKeys()
+	series, variants := processPages(keys, stream)
+	checkLinks(series)
+	if n := printErrors(); n > 0 {
+		log.Error().Int("errors", n).Msg("build failure")
 	}
+	writeIndex(stream)
+	log.Info().Dur("took", time.Since(start)).Msg("built")
 
-	return res
+	cache, err := buildcache.Open(cacheDir)
+	if err != nil {
+		log.Fatal().Err(err).Msg("open cache failure")
+	}
+	if err := stream.Done(); err != nil {
+		log.Fatal().Err(err).Msg("stream failure")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	roots := []string{"pages", "templates"}
+	err = devserver.Watch(ctx, md, roots, 250*time.Millisecond, func(changed []string, removed []string) {
+		start := time.Now()
+		series = rebuild(cache, variants, keys, stream, series, changed, removed)
+		if n := printErrors(); n > 0 {
+			log.Error().Int("errors", n).Strs("files", changed).Strs("removed", removed).Msg("rebuild failure")
+		} else {
+			log.Info().Strs("files", changed).Strs("removed", removed).Dur("took", time.Since(start)).Msg("rebuilt")
+		}
+		if err := stream.Done(); err != nil {
+			log.Fatal().Err(err).Msg("stream failure")
+		}
+	})
+	if err != nil && ctx.Err() == nil {
+		log.Fatal().Err(err).Msg("watch failure")
+	}
 }
 
 func main() {
-	processPages()
+	if len(os.Args) > 1 && os.Args[1] == "dev" {
+		dev()
+		return
+	}
+
+	_ = os.RemoveAll("gen")
+	series, _ := processPages(nil, diskOutput{})
+	checkLinks(series)
+	if n := printErrors(); n > 0 {
+		log.Fatal().Int("errors", n).Msg("build failure")
+	}
 	index.Generate()
 }
@@ -0,0 +1,194 @@
+//go:build build
+
+// Package buildcache is a persistent, content-addressed cache for the build pipeline.
+//
+// Each entry is a set of generated files stored under a key computed from every
+// input of the generation (sources, assets, templates, options...).
+package buildcache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"sort"
+	"sync"
+)
+
+// Entry is the set of files produced for a key, indexed by file name.
+type Entry map[string][]byte
+
+// Cache is a directory of entries. It is safe for concurrent use.
+type Cache struct {
+	dir string
+
+	mu   sync.Mutex
+	used map[string]struct{}
+}
+
+// Open opens or creates the cache in dir.
+func Open(dir string) (*Cache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &Cache{
+		dir:  dir,
+		used: make(map[string]struct{}),
+	}, nil
+}
+
+// Load returns the entry stored under key.
+func (c *Cache) Load(key string) (Entry, bool) {
+	c.markUsed(key)
+	entries, err := os.ReadDir(filepath.Join(c.dir, key))
+	if err != nil {
+		return nil, false
+	}
+	e := make(Entry, len(entries))
+	for _, entry := range entries {
+		b, err := os.ReadFile(filepath.Join(c.dir, key, entry.Name()))
+		if err != nil {
+			return nil, false
+		}
+		e[entry.Name()] = b
+	}
+	return e, true
+}
+
+// Save stores the entry under key.
+//
+// The entry is written in a temporary directory first, then renamed, so that an
+// interrupted build never leaves a partial entry behind and a concurrent Load
+// never sees one. An entry already stored under key is kept: the entries of a
+// key are identical.
+func (c *Cache) Save(key string, e Entry) error {
+	c.markUsed(key)
+	tmp, err := os.MkdirTemp(c.dir, "tmp-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+	for name, b := range e {
+		if err := os.WriteFile(filepath.Join(tmp, name), b, 0o644); err != nil {
+			return err
+		}
+	}
+	dst := filepath.Join(c.dir, key)
+	if err := os.Rename(tmp, dst); err != nil {
+		if _, statErr := os.Stat(dst); statErr == nil {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+func (c *Cache) markUsed(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.used[key] = struct{}{}
+}
+
+// Prune removes every entry that wasn't loaded or saved since Open.
+func (c *Cache) Prune() error {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var errs []error
+	for _, entry := range entries {
+		if _, ok := c.used[entry.Name()]; ok {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(c.dir, entry.Name())); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Key computes a cache key from the inputs of a generation.
+type Key struct {
+	h hash.Hash
+}
+
+// NewKey returns a new Key.
+//
+// The key is seeded with the versions of Go and of the modules linked in the
+// build program, replaced or not, so that upgrading a renderer (d2, chroma,
+// the image encoders...) invalidates the cache.
+func NewKey() *Key {
+	k := &Key{h: sha256.New()}
+	if info, ok := debug.ReadBuildInfo(); ok {
+		k.String(info.GoVersion)
+		for _, dep := range info.Deps {
+			if dep.Replace != nil {
+				dep = dep.Replace
+			}
+			k.String(dep.Path + "@" + dep.Version + "\n")
+		}
+	}
+	return k
+}
+
+// Bytes adds b to the key.
+func (k *Key) Bytes(b []byte) *Key {
+	// Length-prefixed so that ("ab", "c") and ("a", "bc") do not collide.
+	fmt.Fprintf(k.h, "%d:", len(b))
+	k.h.Write(b)
+	return k
+}
+
+// String adds s to the key.
+func (k *Key) String(s string) *Key {
+	return k.Bytes([]byte(s))
+}
+
+// File adds the name and the content of a file to the key.
+func (k *Key) File(fsys fs.FS, name string) error {
+	b, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return err
+	}
+	k.String(name).Bytes(b)
+	return nil
+}
+
+// Dir adds every file under dir to the key. A missing dir is ignored.
+func (k *Key) Dir(fsys fs.FS, dir string) error {
+	var names []string
+	err := fs.WalkDir(fsys, dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			names = append(names, path)
+		}
+		return nil
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := k.File(fsys, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Sum returns the key.
+func (k *Key) Sum() string {
+	return hex.EncodeToString(k.h.Sum(nil))
+}
@@ -0,0 +1,56 @@
+//go:build build
+
+package buildcache_test
+
+import (
+	"sync"
+	"testing"
+	"testing/fstest"
+
+	"github.com/Darkness4/blog/web/buildcache"
+)
+
+func TestKeyFile(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a": {Data: []byte("bc")},
+		"b": {Data: []byte("")},
+	}
+	sum := func(name string, extra string) string {
+		k := buildcache.NewKey()
+		if err := k.File(fsys, name); err != nil {
+			t.Fatal(err)
+		}
+		return k.String(extra).Sum()
+	}
+	// The content of a file does not alias the field after it.
+	if sum("a", "") == sum("b", "bc") {
+		t.Error("expected different keys")
+	}
+	if sum("a", "x") != sum("a", "x") {
+		t.Error("expected the same key")
+	}
+}
+
+func TestCacheSaveConcurrent(t *testing.T) {
+	cache, err := buildcache.Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := buildcache.NewKey().String("image").Sum()
+	entry := buildcache.Entry{"a.png": []byte("png"), "a-480w.png": []byte("small")}
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := cache.Save(key, entry); err != nil {
+				t.Error(err)
+			}
+			if e, ok := cache.Load(key); !ok || len(e) != len(entry) {
+				t.Errorf("expected the whole entry, got %v", e)
+			}
+		}()
+	}
+	wg.Wait()
+}
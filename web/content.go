@@ -0,0 +1,112 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/Darkness4/blog/web/devserver"
+	"github.com/rs/zerolog/log"
+)
+
+// contentFiles are the files required in a content directory.
+var contentFiles = []string{"base.html", "base.htmx", "error.tmpl", "gen/pages", "components", "static"}
+
+// Content is the file system of the served content: the generated pages, the
+// templates and the static files. It can be replaced atomically while
+// serving. It is safe for concurrent use.
+type Content struct {
+	current atomic.Pointer[snapshot]
+}
+
+type snapshot struct {
+	fs.FS
+	// articles are the contents of the feed items, by href (see
+	// FeedContentFunc).
+	articles sync.Map
+}
+
+// NewContent returns the content of fsys.
+func NewContent(fsys fs.FS) *Content {
+	c := &Content{}
+	c.Replace(fsys)
+	return c
+}
+
+// Open implements fs.FS.
+func (c *Content) Open(name string) (fs.File, error) {
+	return c.current.Load().Open(name)
+}
+
+// Replace replaces the content by fsys. The requests being served keep the
+// previous content.
+func (c *Content) Replace(fsys fs.FS) {
+	c.current.Store(&snapshot{FS: fsys})
+}
+
+// LoadDir reads a content directory in memory, so that the later changes of
+// the directory are not seen until it is loaded again.
+//
+// The directory is laid out as the web directory after "go generate": gen,
+// components, static, base.html... The index of the pages is compiled in the
+// binary, so the pages must be generated from the same sources: the content
+// can be fixed without a rebuild, but a new page needs one.
+func LoadDir(dir string) (fs.FS, error) {
+	fsys := os.DirFS(dir)
+	for _, name := range contentFiles {
+		if _, err := fs.Stat(fsys, name); err != nil {
+			return nil, fmt.Errorf("invalid content directory: %w", err)
+		}
+	}
+	files, err := copyFS(fsys)
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// WatchDir loads the content directory dir again on SIGHUP, or when its files
+// change (polled every interval), until ctx is done. If interval is 0, the
+// directory is only loaded on SIGHUP.
+//
+// If the directory fails to load, the previous content is kept.
+func (c *Content) WatchDir(ctx context.Context, dir string, interval time.Duration) {
+	reload := func(reason string) {
+		fsys, err := LoadDir(dir)
+		if err != nil {
+			log.Err(err).Str("dir", dir).Msg("failed to reload content, keeping the previous one")
+			return
+		}
+		c.Replace(fsys)
+		log.Info().Str("dir", dir).Str("reason", reason).Msg("content reloaded")
+	}
+
+	if interval > 0 {
+		go func() {
+			err := devserver.Watch(ctx, os.DirFS(dir), []string{"."}, interval, func([]string, []string) {
+				reload("change")
+			})
+			if err != nil && ctx.Err() == nil {
+				log.Err(err).Str("dir", dir).Msg("failed to watch content")
+			}
+		}()
+	}
+
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			reload("SIGHUP")
+		}
+	}
+}
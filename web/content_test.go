@@ -0,0 +1,90 @@
+package web_test
+
+import (
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/Darkness4/blog/web"
+)
+
+func writeContent(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, data := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestContent(t *testing.T) {
+	dir := t.TempDir()
+	writeContent(t, dir, map[string]string{
+		"base.html":                "base",
+		"base.htmx":                "base",
+		"error.tmpl":               "error",
+		"components/nav.html":      "nav",
+		"static/app.css":           "body {}",
+		"gen/pages/page.tmpl":      "home v1",
+		"gen/pages/blog/page.tmpl": "blog",
+	})
+
+	fsys, err := web.LoadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := fstest.TestFS(fsys, "base.html", "components/nav.html", "gen/pages/blog/page.tmpl"); err != nil {
+		t.Error(err)
+	}
+	content := web.NewContent(web.Embedded())
+	content.Replace(fsys)
+
+	// The content is a snapshot of the directory.
+	writeContent(t, dir, map[string]string{"gen/pages/page.tmpl": "home v2"})
+	if b, _ := fs.ReadFile(content, "gen/pages/page.tmpl"); string(b) != "home v1" {
+		t.Errorf("expected the loaded page, got %q", b)
+	}
+	fsys, err = web.LoadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content.Replace(fsys)
+	if b, _ := fs.ReadFile(content, "gen/pages/page.tmpl"); string(b) != "home v2" {
+		t.Errorf("expected the reloaded page, got %q", b)
+	}
+
+	// Only the static files are served as static files.
+	static := web.StaticFunc(content)
+	for path, expected := range map[string]int{
+		"/static/app.css":                http.StatusOK,
+		"/static/../gen/pages/page.tmpl": http.StatusNotFound,
+		"/static/%2e%2e/base.html":       http.StatusNotFound,
+		"/static/missing.css":            http.StatusNotFound,
+	} {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.URL.Path = path
+		static.ServeHTTP(rec, req)
+		body, _ := io.ReadAll(rec.Body)
+		if rec.Code != expected {
+			t.Errorf("%s: expected status %d, got %d: %s", path, expected, rec.Code, body)
+		}
+	}
+}
+
+func TestLoadDirInvalid(t *testing.T) {
+	dir := t.TempDir()
+	writeContent(t, dir, map[string]string{"base.html": "base"})
+	if _, err := web.LoadDir(dir); err == nil {
+		t.Error("expected an error for a directory without the templates")
+	}
+}
@@ -0,0 +1,283 @@
+// Package devserver serves the pages while they are being written.
+//
+// The generator watches the sources and streams the pages it rebuilds to the
+// server, which serves them from memory and reloads the browsers.
+package devserver
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// IndexName is the name of the entries of the index in the overlay, in the
+// JSON of the generated index package. The server loads them at the end of
+// each build.
+const IndexName = "gen/index/entries.json"
+
+// Message is a message of the generator to the server: a generated file, a
+// removed file or directory, or the end of a build.
+type Message struct {
+	Name    string `json:"name,omitempty"`
+	Data    []byte `json:"data,omitempty"`
+	Removed bool   `json:"removed,omitempty"`
+	Done    bool   `json:"done,omitempty"`
+}
+
+// Stream sends the generated files to the server, one JSON message per line.
+// It is safe for concurrent use.
+type Stream struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewStream returns a Stream writing to w.
+func NewStream(w io.Writer) *Stream {
+	return &Stream{enc: json.NewEncoder(w)}
+}
+
+// WriteFile sends a generated file.
+func (s *Stream) WriteFile(name string, data []byte) error {
+	return s.send(Message{Name: name, Data: data})
+}
+
+// Remove sends the removal of a generated file or directory.
+func (s *Stream) Remove(name string) error {
+	return s.send(Message{Name: name, Removed: true})
+}
+
+// Done signals the end of a build.
+func (s *Stream) Done() error {
+	return s.send(Message{Done: true})
+}
+
+func (s *Stream) send(m Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.enc.Encode(m)
+}
+
+// maxMessageSize is the maximum size of a message of a Stream.
+const maxMessageSize = 64 << 20
+
+// Apply writes and removes the files sent by a Stream in the overlay, and
+// calls onDone at the end of each build, until r is closed. The lines which are not messages
+// are copied to the standard error.
+func (o *Overlay) Apply(r io.Reader, onDone func()) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64<<10), maxMessageSize)
+	for scanner.Scan() {
+		var m Message
+		if err := json.Unmarshal(scanner.Bytes(), &m); err != nil {
+			fmt.Fprintln(os.Stderr, scanner.Text())
+			continue
+		}
+		switch {
+		case m.Done:
+			onDone()
+		case m.Removed:
+			if err := o.Remove(m.Name); err != nil {
+				return err
+			}
+		case m.Name != "":
+			if err := o.WriteFile(m.Name, m.Data); err != nil {
+				return err
+			}
+		}
+	}
+	return scanner.Err()
+}
+
+// Watch calls onChange with the files of the roots in fsys created or
+// modified, and the ones removed, since the last call, every interval, until
+// ctx is done.
+//
+// The files are polled, so it works on every file system.
+func Watch(
+	ctx context.Context,
+	fsys fs.FS,
+	roots []string,
+	interval time.Duration,
+	onChange func(changed []string, removed []string),
+) error {
+	prev, err := scan(fsys, roots)
+	if err != nil {
+		return err
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+		curr, err := scan(fsys, roots)
+		if err != nil {
+			return err
+		}
+		var changed, removed []string
+		for name, stamp := range curr {
+			if prev[name] != stamp {
+				changed = append(changed, name)
+			}
+		}
+		for name := range prev {
+			if _, ok := curr[name]; !ok {
+				removed = append(removed, name)
+			}
+		}
+		prev = curr
+		if len(changed) > 0 || len(removed) > 0 {
+			slices.Sort(changed)
+			slices.Sort(removed)
+			onChange(changed, removed)
+		}
+	}
+}
+
+type stamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// scan returns the modification time and the size of the files of the roots.
+func scan(fsys fs.FS, roots []string) (map[string]stamp, error) {
+	files := make(map[string]stamp)
+	for _, root := range roots {
+		err := fs.WalkDir(fsys, root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			files[path] = stamp{modTime: info.ModTime(), size: info.Size()}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// EventsPath is the path of the Server-Sent Events stream of the reloads.
+const EventsPath = "/_dev/events"
+
+// reloadScript reloads the page on each event of the stream. It survives the
+// HTMX navigations, which do not replace the window.
+const reloadScript = `<script>
+if (!window.devEvents) {
+  window.devEvents = new EventSource("` + EventsPath + `");
+  window.devEvents.addEventListener("reload", () => location.reload());
+}
+</script>`
+
+// Reloader reloads the browsers showing the pages. It is safe for concurrent
+// use.
+type Reloader struct {
+	mu      sync.Mutex
+	clients map[chan struct{}]struct{}
+}
+
+// NewReloader returns a Reloader without clients.
+func NewReloader() *Reloader {
+	return &Reloader{clients: make(map[chan struct{}]struct{})}
+}
+
+// Reload reloads every connected browser.
+func (rl *Reloader) Reload() {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	for c := range rl.clients {
+		select {
+		case c <- struct{}{}:
+		default:
+			// A reload is already pending.
+		}
+	}
+}
+
+// ServeHTTP streams the reloads to a browser.
+func (rl *Reloader) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	c := make(chan struct{}, 1)
+	rl.mu.Lock()
+	rl.clients[c] = struct{}{}
+	rl.mu.Unlock()
+	defer func() {
+		rl.mu.Lock()
+		delete(rl.clients, c)
+		rl.mu.Unlock()
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-c:
+			if _, err := fmt.Fprint(w, "event: reload\ndata: {}\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// Inject adds the reload script at the end of the body of the HTML pages
+// served by next.
+func (rl *Reloader) Inject(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bw := &bufferedWriter{ResponseWriter: w}
+		next.ServeHTTP(bw, r)
+		body := bw.buf.Bytes()
+		if strings.HasPrefix(w.Header().Get("Content-Type"), "text/html") ||
+			w.Header().Get("Content-Type") == "" {
+			if i := bytes.LastIndex(body, []byte("</body>")); i >= 0 {
+				body = append(body[:i:i], append([]byte(reloadScript), body[i:]...)...)
+			}
+		}
+		w.Header().Del("Content-Length")
+		if bw.code != 0 {
+			w.WriteHeader(bw.code)
+		}
+		_, _ = w.Write(body)
+	})
+}
+
+// bufferedWriter holds the response until it is complete.
+type bufferedWriter struct {
+	http.ResponseWriter
+	buf  bytes.Buffer
+	code int
+}
+
+func (w *bufferedWriter) WriteHeader(code int) {
+	if w.code == 0 {
+		w.code = code
+	}
+}
+
+func (w *bufferedWriter) Write(b []byte) (int, error) {
+	return w.buf.Write(b)
+}
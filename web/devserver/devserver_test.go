@@ -0,0 +1,171 @@
+package devserver_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/Darkness4/blog/web/devserver"
+)
+
+func TestOverlayApply(t *testing.T) {
+	base := fstest.MapFS{
+		"gen/pages/page.tmpl":      {Data: []byte("old")},
+		"gen/pages/blog/a/page.md": {Data: []byte("source")},
+	}
+	overlay := devserver.NewOverlay(base)
+
+	var stream bytes.Buffer
+	s := devserver.NewStream(&stream)
+	_ = s.WriteFile("gen/pages/page.tmpl", []byte("new"))
+	_ = s.WriteFile("gen/pages/blog/b/page.tmpl", []byte("added"))
+	_ = s.Done()
+
+	done := 0
+	if err := overlay.Apply(&stream, func() { done++ }); err != nil {
+		t.Fatal(err)
+	}
+	if done != 1 {
+		t.Errorf("expected 1 build, got %d", done)
+	}
+	for name, expected := range map[string]string{
+		"gen/pages/page.tmpl":        "new",
+		"gen/pages/blog/b/page.tmpl": "added",
+		"gen/pages/blog/a/page.md":   "source",
+	} {
+		b, err := fs.ReadFile(overlay, name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != expected {
+			t.Errorf("%s: expected %q, got %q", name, expected, b)
+		}
+	}
+	if info, err := fs.Stat(overlay, "gen/pages/blog/b"); err != nil || !info.IsDir() {
+		t.Errorf("expected the directory of an added page, got %v, %v", info, err)
+	}
+	if err := fstest.TestFS(overlay, "gen/pages/page.tmpl", "gen/pages/blog/a/page.md", "gen/pages/blog/b/page.tmpl"); err != nil {
+		t.Error(err)
+	}
+
+	// The removed files hide the ones of the base, until they are written
+	// again.
+	_ = s.Remove("gen/pages/blog")
+	_ = s.WriteFile("gen/pages/blog/c/page.tmpl", []byte("rewritten"))
+	_ = s.Done()
+	if err := overlay.Apply(&stream, func() { done++ }); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"gen/pages/blog/a/page.md", "gen/pages/blog/b/page.tmpl"} {
+		if _, err := fs.Stat(overlay, name); !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("%s: expected a removed file, got %v", name, err)
+		}
+	}
+	entries, err := fs.ReadDir(overlay, "gen/pages/blog")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Name() != "c" {
+		t.Errorf("expected the rewritten page only, got %v", entries)
+	}
+	if err := fstest.TestFS(overlay, "gen/pages/page.tmpl", "gen/pages/blog/c/page.tmpl"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestOverlayReadDir(t *testing.T) {
+	base := fstest.MapFS{
+		"gen/pages/blog/a/page.tmpl": {Data: []byte("a")},
+		"static/style.css":           {Data: []byte("body {}")},
+	}
+	overlay := devserver.NewOverlay(base)
+	_ = overlay.WriteFile("gen/pages/blog/b/page.tmpl", []byte("b"))
+	_ = overlay.WriteFile("gen/articles/blog/b.html", []byte("b"))
+
+	for dir, expected := range map[string][]string{
+		".":              {"gen", "static"},
+		"gen":            {"articles", "pages"},
+		"gen/pages/blog": {"a", "b"},
+	} {
+		entries, err := fs.ReadDir(overlay, dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var names []string
+		for _, e := range entries {
+			names = append(names, e.Name())
+		}
+		if !slices.Equal(names, expected) {
+			t.Errorf("%s: expected %v, got %v", dir, expected, names)
+		}
+	}
+}
+
+func TestWatch(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Join(dir, filepath.Dir(name)), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(name), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("pages/a/page.md")
+	write("pages/b/page.md")
+	write("templates/page.tmpl")
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	var changed, removed []string
+	errs := make(chan error, 1)
+	go func() {
+		errs <- devserver.Watch(ctx, os.DirFS(dir), []string{"pages", "templates"}, 10*time.Millisecond, func(c, r []string) {
+			changed, removed = c, r
+			cancel()
+		})
+	}()
+	// The first scan must happen before the changes.
+	time.Sleep(50 * time.Millisecond)
+	write("templates/page.tmpl.new")
+	if err := os.Remove(filepath.Join(dir, "pages/b/page.md")); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-errs; !errors.Is(err, context.Canceled) {
+		t.Fatal(err)
+	}
+	if !slices.Equal(changed, []string{"templates/page.tmpl.new"}) {
+		t.Errorf("expected the new template to be changed, got %v", changed)
+	}
+	if !slices.Equal(removed, []string{"pages/b/page.md"}) {
+		t.Errorf("expected the removed page, got %v", removed)
+	}
+}
+
+func TestInject(t *testing.T) {
+	h := devserver.NewReloader().Inject(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte("<html><body><p>Not found</p></body></html>"))
+	}))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, rec.Code)
+	}
+	body := rec.Body.String()
+	if !strings.Contains(body, devserver.EventsPath+`");`) || !strings.HasSuffix(body, "</script></body></html>") {
+		t.Errorf("expected the reload script at the end of the body, got %s", body)
+	}
+}
@@ -0,0 +1,220 @@
+package devserver
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Overlay is a file system serving the files written in memory over a base
+// file system. It is safe for concurrent use.
+//
+// The directories list the entries of both layers. The files and the
+// directories removed from the overlay hide the ones of the base.
+type Overlay struct {
+	base fs.FS
+
+	mu    sync.RWMutex
+	files map[string]*memFile
+	// removed are the removed names. They hide the files of the base below
+	// them, but not the files written in memory since.
+	removed map[string]bool
+}
+
+// NewOverlay returns an empty overlay over base.
+func NewOverlay(base fs.FS) *Overlay {
+	return &Overlay{
+		base:    base,
+		files:   make(map[string]*memFile),
+		removed: make(map[string]bool),
+	}
+}
+
+// WriteFile writes a file in memory. The name is a slash-separated path
+// relative to the root of the overlay.
+func (o *Overlay) WriteFile(name string, data []byte) error {
+	name = path.Clean(name)
+	if !fs.ValidPath(name) || name == "." {
+		return &fs.PathError{Op: "write", Path: name, Err: fs.ErrInvalid}
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.files[name] = &memFile{data: bytes.Clone(data), modTime: time.Now()}
+	return nil
+}
+
+// Remove removes a file or a directory and its content, in memory and from
+// the view of the base.
+func (o *Overlay) Remove(name string) error {
+	name = path.Clean(name)
+	if !fs.ValidPath(name) {
+		return &fs.PathError{Op: "remove", Path: name, Err: fs.ErrInvalid}
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for f := range o.files {
+		if within(f, name) {
+			delete(o.files, f)
+		}
+	}
+	o.removed[name] = true
+	return nil
+}
+
+// within reports whether name is dir or a path below it.
+func within(name string, dir string) bool {
+	return dir == "." || name == dir || strings.HasPrefix(name, dir+"/")
+}
+
+// hidden reports whether the file of the base at name is removed. o.mu must
+// be held.
+func (o *Overlay) hidden(name string) bool {
+	for r := range o.removed {
+		if within(name, r) {
+			return true
+		}
+	}
+	return false
+}
+
+// Open implements fs.FS.
+func (o *Overlay) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	if f, ok := o.files[name]; ok {
+		return &openFile{
+			Reader: bytes.NewReader(f.data),
+			info:   fileInfo{name: path.Base(name), size: int64(len(f.data)), modTime: f.modTime},
+		}, nil
+	}
+
+	// The entries of the directory in memory.
+	entries := make(map[string]fs.DirEntry)
+	for f, file := range o.files {
+		rel, ok := strings.CutPrefix(f, name+"/")
+		if name == "." {
+			rel, ok = f, true
+		}
+		if !ok {
+			continue
+		}
+		child, _, isDir := strings.Cut(rel, "/")
+		info := fileInfo{name: child, size: int64(len(file.data)), modTime: file.modTime}
+		if isDir {
+			info = fileInfo{name: child, dir: true}
+		}
+		if _, ok := entries[child]; !ok {
+			entries[child] = fs.FileInfoToDirEntry(info)
+		}
+	}
+
+	var info fs.FileInfo = fileInfo{name: path.Base(name), dir: true}
+	if !o.hidden(name) {
+		base, err := fs.Stat(o.base, name)
+		switch {
+		case err != nil && (len(entries) == 0 || !errors.Is(err, fs.ErrNotExist)):
+			return nil, err
+		case err == nil && !base.IsDir() && len(entries) == 0:
+			return o.base.Open(name)
+		case err == nil && base.IsDir():
+			info = base
+			baseEntries, err := fs.ReadDir(o.base, name)
+			if err != nil {
+				return nil, err
+			}
+			// The files in memory hide the ones of the base, and the
+			// directories of the base are described by the base.
+			for _, e := range baseEntries {
+				mem, ok := entries[e.Name()]
+				if (!ok || mem.IsDir() && e.IsDir()) && !o.hidden(path.Join(name, e.Name())) {
+					entries[e.Name()] = e
+				}
+			}
+		}
+	} else if len(entries) == 0 {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+
+	dir := &openDir{info: info, entries: make([]fs.DirEntry, 0, len(entries))}
+	for _, e := range entries {
+		dir.entries = append(dir.entries, e)
+	}
+	slices.SortFunc(dir.entries, func(a, b fs.DirEntry) int {
+		return strings.Compare(a.Name(), b.Name())
+	})
+	return dir, nil
+}
+
+// memFile is a file written in memory.
+type memFile struct {
+	data    []byte
+	modTime time.Time
+}
+
+// fileInfo describes a file or a directory in memory.
+type fileInfo struct {
+	name    string
+	size    int64
+	modTime time.Time
+	dir     bool
+}
+
+func (fi fileInfo) Name() string       { return fi.name }
+func (fi fileInfo) Size() int64        { return fi.size }
+func (fi fileInfo) ModTime() time.Time { return fi.modTime }
+func (fi fileInfo) IsDir() bool        { return fi.dir }
+func (fi fileInfo) Sys() any           { return nil }
+
+func (fi fileInfo) Mode() fs.FileMode {
+	if fi.dir {
+		return fs.ModeDir | 0o755
+	}
+	return 0o644
+}
+
+// openFile is an open file of the overlay. It can be seeked, to be served
+// with http.FS.
+type openFile struct {
+	*bytes.Reader
+	info fileInfo
+}
+
+func (f *openFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+func (f *openFile) Close() error               { return nil }
+
+// openDir is an open directory of the overlay, with the entries of both
+// layers.
+type openDir struct {
+	info    fs.FileInfo
+	entries []fs.DirEntry
+	offset  int
+}
+
+func (d *openDir) Stat() (fs.FileInfo, error) { return d.info, nil }
+func (d *openDir) Close() error               { return nil }
+
+func (d *openDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.info.Name(), Err: fs.ErrInvalid}
+}
+
+// ReadDir implements fs.ReadDirFile.
+func (d *openDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	rest := d.entries[d.offset:]
+	if n > 0 && len(rest) == 0 {
+		return nil, io.EOF
+	}
+	if n > 0 && n < len(rest) {
+		rest = rest[:n]
+	}
+	d.offset += len(rest)
+	return rest, nil
+}
@@ -0,0 +1,116 @@
+package web
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Darkness4/blog/web/gen/index"
+)
+
+// Export writes the published pages of the content, their assets and the
+// static files to dir, to be served by a static host, and returns the number
+// of written pages.
+//
+// A page is written to {path}/index.html, with base.html, and the 404 page of
+// each language to {prefix}/404.html. The static hosts ignore the query, so
+// only the first page of the paginated pages is written: the older pages are
+// reached through the archive.
+func (p *Pages) Export(dir string) (int, error) {
+	now := time.Now()
+	c := p.load(now)
+	paths, err := pagePaths(c.fsys, now)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, pagePath := range paths {
+		view, ok := p.resolve(c.fsys, pagePath, now)
+		if !ok {
+			continue
+		}
+		rp, err := c.page(view, 0, false)
+		if err != nil {
+			return count, fmt.Errorf("%s: %w", pagePath, err)
+		}
+		if err := writeFile(dir, path.Join(view.data.Path, "index.html"), rp.body); err != nil {
+			return count, err
+		}
+		count++
+	}
+	for _, lang := range index.Languages {
+		rp, err := c.notFound(lang, false)
+		if err != nil {
+			return count, err
+		}
+		if err := writeFile(dir, path.Join(index.LanguagePrefix(lang), "404.html"), rp.body); err != nil {
+			return count, err
+		}
+	}
+
+	// The assets of the written pages, without the generated files of the
+	// pages, and the static files.
+	written := make(map[string]bool, len(paths))
+	for _, pagePath := range paths {
+		written[pagePath] = true
+	}
+	if err := copyDir(dir, c.fsys, "gen/pages", "/", func(name string) bool {
+		if slices.Contains(pageFiles, path.Base(name)) {
+			return false
+		}
+		owner, ok := pageOf(c.fsys, name)
+		return !ok || written[owner]
+	}); err != nil {
+		return count, err
+	}
+	if err := copyDir(dir, c.fsys, "static", "/static", func(string) bool {
+		return true
+	}); err != nil {
+		return count, err
+	}
+	return count, nil
+}
+
+// pageOf returns the path of the page owning the file at fpath in gen/pages:
+// the closest directory with a page template.
+func pageOf(fsys fs.FS, fpath string) (string, bool) {
+	for dir := path.Dir(fpath); strings.HasPrefix(dir, "gen/pages"); dir = path.Dir(dir) {
+		if _, err := fs.Stat(fsys, path.Join(dir, "page.tmpl")); err == nil {
+			return path.Clean("/" + strings.TrimPrefix(dir, "gen/pages")), true
+		}
+	}
+	return "", false
+}
+
+// copyDir copies the files of root in fsys which match keep to prefix in dir.
+func copyDir(dir string, fsys fs.FS, root, prefix string, keep func(name string) bool) error {
+	return fs.WalkDir(fsys, root, func(fpath string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !keep(fpath) {
+			return err
+		}
+		b, err := fs.ReadFile(fsys, fpath)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, fpath)
+		if err != nil {
+			return err
+		}
+		return writeFile(dir, path.Join(prefix, filepath.ToSlash(rel)), b)
+	})
+}
+
+// writeFile writes a file at the slash-separated path name in dir.
+func writeFile(dir string, name string, data []byte) error {
+	fpath := filepath.Join(dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(fpath), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(fpath, data, 0o644)
+}
@@ -0,0 +1,46 @@
+package web_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/Darkness4/blog/web"
+)
+
+func TestPagesExport(t *testing.T) {
+	fsys := newPagesFS("v1")
+	fsys["gen/pages/test/page.assets/diagram.svg"] = &fstest.MapFile{Data: []byte("<svg/>")}
+	fsys["gen/pages/test/page.html"] = &fstest.MapFile{Data: []byte("<p>article</p>")}
+	fsys["static/app.css"] = &fstest.MapFile{Data: []byte("body {}")}
+	dir := t.TempDir()
+
+	n, err := web.NewPages(fsys, "https://example.com", nil).Export(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 {
+		t.Errorf("expected 1 page, got %d", n)
+	}
+	for name, expected := range map[string]string{
+		"test/index.html":              "<html>v1 /test</html>",
+		"404.html":                     "<html>404</html>",
+		"test/page.assets/diagram.svg": "<svg/>",
+		"static/app.css":               "body {}",
+	} {
+		b, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if string(b) != expected {
+			t.Errorf("%s: expected %q, got %q", name, expected, b)
+		}
+	}
+	for _, name := range []string{"test/page.tmpl", "test/page.html"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+			t.Errorf("expected %s not to be exported", name)
+		}
+	}
+}
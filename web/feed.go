@@ -0,0 +1,74 @@
+package web
+
+import (
+	"errors"
+	"io/fs"
+	"net/url"
+	"path"
+	"regexp"
+	"sync"
+
+	"github.com/Darkness4/blog/web/gen/index"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	// rootURLAttr matches the href and src attributes with a URL relative to
+	// the root of the site.
+	rootURLAttr = regexp.MustCompile(`(\s(?:href|src)=")/([^/])`)
+	// fragmentAttr matches the links to the fragments of the page.
+	fragmentAttr = regexp.MustCompile(`(\shref=")#`)
+	// srcsetAttr matches the srcset attributes, and rootURLCandidate the
+	// candidates of their values relative to the root of the site.
+	srcsetAttr       = regexp.MustCompile(`\ssrcset="[^"]*"`)
+	rootURLCandidate = regexp.MustCompile(`(srcset="|,\s*)/([^/])`)
+)
+
+// FeedContentFunc returns the content of the feed items of the entries: their
+// article rendered in the content fsys, with absolute URLs. The articles are
+// generated in gen/articles, out of the served pages.
+//
+// The contents are read once per snapshot of a Content, or once for the other
+// file systems.
+//
+// The content is empty if the article was not rendered, e.g. in the content
+// of an older build.
+func FeedContentFunc(fsys fs.FS) func(index.Index) string {
+	var articles sync.Map
+	return func(entry index.Index) string {
+		src, cache := fsys, &articles
+		if c, ok := fsys.(*Content); ok {
+			snap := c.current.Load()
+			src, cache = snap, &snap.articles
+		}
+		if content, ok := cache.Load(entry.Href); ok {
+			return content.(string)
+		}
+		content := ""
+		b, err := fs.ReadFile(src, path.Join("gen/articles", entry.Href+".html"))
+		switch {
+		case err == nil:
+			content = absoluteURLs(string(b), entry.Loc)
+		case !errors.Is(err, fs.ErrNotExist):
+			log.Err(err).Str("href", entry.Href).Msg("failed to read article")
+			return ""
+		}
+		cache.Store(entry.Href, content)
+		return content
+	}
+}
+
+// absoluteURLs makes the URLs of an HTML document at loc relative to the root
+// of the site or to the document absolute.
+func absoluteURLs(html string, loc string) string {
+	u, err := url.Parse(loc)
+	if err != nil {
+		return html
+	}
+	origin := u.Scheme + "://" + u.Host
+	html = fragmentAttr.ReplaceAllString(html, "${1}"+loc+"#")
+	html = rootURLAttr.ReplaceAllString(html, "${1}"+origin+"/${2}")
+	return srcsetAttr.ReplaceAllStringFunc(html, func(attr string) string {
+		return rootURLCandidate.ReplaceAllString(attr, "${1}"+origin+"/${2}")
+	})
+}
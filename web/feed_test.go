@@ -0,0 +1,53 @@
+package web_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/Darkness4/blog/web"
+	"github.com/Darkness4/blog/web/gen/index"
+)
+
+func TestFeedContentFunc(t *testing.T) {
+	fsys := fstest.MapFS{
+		"gen/articles/blog/post.html": {Data: []byte(`<p><a href="#intro">Intro</a> <a href="/blog/other">Other</a> ` +
+			`<a href="https://example.org/">External</a> <img src="//cdn.example.org/a.png">, /not/a/link</p>` +
+			`<img src="/blog/post/page.assets/a.png" srcset="/blog/post/page.assets/a-480w.png 480w, /blog/post/page.assets/a.png 960w">`)},
+	}
+	content := web.FeedContentFunc(fsys)
+
+	got := content(index.Index{Href: "/blog/post", Loc: "https://example.com/blog/post"})
+	expected := `<p><a href="https://example.com/blog/post#intro">Intro</a> <a href="https://example.com/blog/other">Other</a> ` +
+		`<a href="https://example.org/">External</a> <img src="//cdn.example.org/a.png">, /not/a/link</p>` +
+		`<img src="https://example.com/blog/post/page.assets/a.png" srcset="https://example.com/blog/post/page.assets/a-480w.png 480w, https://example.com/blog/post/page.assets/a.png 960w">`
+	if got != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, got)
+	}
+
+	if got := content(index.Index{Href: "/blog/missing", Loc: "https://example.com/blog/missing"}); got != "" {
+		t.Errorf("expected no content for a page without article, got %q", got)
+	}
+}
+
+func TestFeedContentFuncContent(t *testing.T) {
+	page := func(s string) fstest.MapFS {
+		return fstest.MapFS{"gen/articles/blog/post.html": {Data: []byte(s)}}
+	}
+	entry := index.Index{Href: "/blog/post", Loc: "https://example.com/blog/post"}
+	fsys := page("v1")
+	content := web.NewContent(fsys)
+	feedContent := web.FeedContentFunc(content)
+	if got := feedContent(entry); got != "v1" {
+		t.Errorf("expected the article, got %q", got)
+	}
+
+	// The articles are read once per snapshot.
+	fsys["gen/articles/blog/post.html"] = page("v2")["gen/articles/blog/post.html"]
+	if got := feedContent(entry); got != "v1" {
+		t.Errorf("expected the cached article, got %q", got)
+	}
+	content.Replace(page("v3"))
+	if got := feedContent(entry); got != "v3" {
+		t.Errorf("expected the article of the new content, got %q", got)
+	}
+}
@@ -26,8 +26,8 @@ type headerInfo struct {
 	end    int // End position (start of next heading or EOF)
 }
 
-// extractHeaders walks the AST and extracts all headers with their content
-func extractHeaders(n ast.Node, source []byte) []*Header {
+// ExtractHeaders walks the AST and extracts all headers with their content
+func ExtractHeaders(n ast.Node, source []byte) []*Header {
 	var headers []*Header
 	var headingInfos []headerInfo
 
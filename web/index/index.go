@@ -5,21 +5,28 @@ package index
 import (
 	"bytes"
 	"embed"
+	"encoding/json"
 	"fmt"
 	"go/format"
-	"io"
+	"mime"
+	"net/url"
 	"os"
 	"path"
 	"path/filepath"
+	"slices"
 	"sort"
+	"strings"
 	"text/template"
 	"time"
 
 	"github.com/Darkness4/blog/utils/blog"
+	"github.com/Darkness4/blog/utils/i18n"
 	"github.com/Masterminds/sprig/v3"
+	mathjax "github.com/litao91/goldmark-mathjax"
 	"github.com/rs/zerolog/log"
 	"github.com/yuin/goldmark"
 	meta "github.com/yuin/goldmark-meta"
+	"github.com/yuin/goldmark/ast"
 	"github.com/yuin/goldmark/parser"
 	"github.com/yuin/goldmark/text"
 )
@@ -50,14 +57,71 @@ type Index struct {
 	Title         string
 	Description   string
 	PublishedDate int64
-	Href          string
-	EntryName     string
-	Tags          []string
-	Hierarchy     []*Header
+	// UpdatedDate is the date of the last update, PublishedDate if the page
+	// was not updated (see blog.UpdatedDate).
+	UpdatedDate int64
+	Href        string
+	Lang        string
+	EntryName   string
+	Tags        []string
+	Authors     []string
+	// Image is the first image of the page, if any.
+	Image       *Image
+	Series      string
+	SeriesOrder int
+	Hierarchy   []*Header
+	Draft       bool
+	Unlisted    bool
+	PublishAt   int64
+	WordCount   int
+	CodeLines   int
+	// ReadingTime is the estimated reading time, in minutes.
+	ReadingTime int64
+	// Related are the hrefs of the related pages, the most related first.
+	Related []string
+
+	terms map[string]int
+}
+
+// Image is an image of a page.
+type Image struct {
+	// URL is the URL of the image, relative to the root of the site if it is
+	// served with the page.
+	URL  string
+	Type string
+	// Size is the size of the image in bytes, 0 if unknown.
+	Size int64
+}
+
+// firstImage returns the first image of a page in dir, served at href.
+func firstImage(doc ast.Node, dir string, href string) *Image {
+	var img *Image
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		image, ok := n.(*ast.Image)
+		if !entering || !ok {
+			return ast.WalkContinue, nil
+		}
+		dest := string(image.Destination)
+		typ := mime.TypeByExtension(path.Ext(dest))
+		if !strings.HasPrefix(typ, "image/") {
+			return ast.WalkContinue, nil
+		}
+		img = &Image{URL: dest, Type: typ}
+		if u, err := url.Parse(dest); err == nil && !u.IsAbs() && !path.IsAbs(dest) {
+			img.URL = path.Join(href, u.Path)
+			if info, err := os.Stat(filepath.Join(dir, filepath.FromSlash(u.Path))); err == nil {
+				img.Size = info.Size()
+			}
+		}
+		return ast.WalkStop, nil
+	})
+	return img
 }
 
-func buildPages() (index [][]Index, err error) {
-	entries, err := os.ReadDir("gen/pages/blog")
+// buildPages returns every blog page, including the unpublished ones, from the
+// newest to the oldest.
+func buildPages() (index []Index, err error) {
+	entries, err := os.ReadDir("pages/blog")
 	if err != nil {
 		return index, err
 	}
@@ -74,72 +138,149 @@ func buildPages() (index [][]Index, err error) {
 			meta.New(
 				meta.WithStoresInDocument(),
 			),
+			// The math is parsed to be excluded from the statistics.
+			mathjax.MathJax,
 		),
 	)
 
-	index = make([][]Index, 0, len(entries)/elementPerPage+1)
-	i := 0
+	// The dates of the commits are the fallback of the updated field.
+	commitDates, err := blog.NewCommitDates()
+	if err != nil {
+		log.Warn().Err(err).Msg("the pages are only updated from their front-matter")
+	}
+
+	index = make([]Index, 0, len(entries))
 	for _, entry := range entries {
 		if !entry.IsDir() {
 			continue
 		}
-		page := i / elementPerPage
-		if page >= len(index) {
-			index = append(index, make([]Index, 0, elementPerPage))
-		}
-		f, err := os.Open(filepath.Join("pages/blog", entry.Name(), "page.md"))
-		if err != nil {
-			continue
-		}
-		finfo, err := f.Stat()
+		files, err := os.ReadDir(filepath.Join("pages/blog", entry.Name()))
 		if err != nil {
-			log.Err(err).
-				Str("entry", entry.Name()).
-				Msg("ignored for index, failed to stat page.md")
 			continue
 		}
-		if finfo.IsDir() {
-			continue
-		}
-		b, err := io.ReadAll(f)
-		if err != nil {
-			log.Fatal().Err(err).Msg("read file failure")
-		}
-		document := markdown.Parser().Parse(text.NewReader(b))
+		// The page in the default language comes first.
+		sort.SliceStable(files, func(i, j int) bool {
+			return files[i].Name() == "page.md" && files[j].Name() != "page.md"
+		})
+		for _, file := range files {
+			lang, ok := blog.PageLanguage(file.Name())
+			if !ok || file.IsDir() || !i18n.Supported(lang) {
+				continue
+			}
+			name := filepath.Join("pages/blog", entry.Name(), file.Name())
+			b, err := os.ReadFile(name)
+			if err != nil {
+				log.Fatal().Err(err).Msg("read file failure")
+			}
+			document := markdown.Parser().Parse(text.NewReader(b))
 
-		// Hierarchy
-		headers := extractHeaders(document, b)
-		hierarchy := buildHierarchy(headers)
+			// Hierarchy
+			headers := ExtractHeaders(document, b)
+			hierarchy := buildHierarchy(headers)
 
-		// Metadata
-		metaData := document.OwnerDocument().Meta()
-		date, err := blog.ExtractDate(entry.Name())
-		if err != nil {
-			log.Fatal().Err(err).Msg("failed to read date")
-		}
-		var tags []string
-		if metaData["tags"] != nil {
-			mTags := metaData["tags"].([]any)
-			tags = make([]string, 0, len(mTags))
-			for _, tag := range mTags {
-				tags = append(tags, fmt.Sprintf("%v", tag))
+			// Metadata
+			fm, err := blog.ParseFrontMatter(name, b)
+			if err != nil {
+				log.Fatal().Err(err).Msg("invalid front-matter")
+			}
+			if fm.Description == "" {
+				fm.Description = blog.Excerpt(document, b)
+			}
+			date, err := blog.ExtractDate(entry.Name())
+			if err != nil {
+				log.Fatal().Err(err).Msg("failed to read date")
+			}
+			committed, err := commitDates.Last(name)
+			if err != nil {
+				log.Fatal().Err(err).Msg("failed to read the commit date")
 			}
+			var series string
+			var seriesOrder int
+			if fm.Series != nil {
+				series, seriesOrder = fm.Series.Name, fm.Series.Order
+			}
+			var publishAt int64
+			if !fm.PublishAt.IsZero() {
+				publishAt = fm.PublishAt.Unix()
+			}
+			stats := blog.CountStats(document, b)
+			index = append(index, Index{
+				EntryName:     entry.Name(),
+				Title:         fm.Title,
+				Description:   fm.Description,
+				PublishedDate: date.Unix(),
+				UpdatedDate:   blog.UpdatedDate(fm, committed, date).Unix(),
+				Href:          blog.Href(name),
+				Lang:          lang,
+				Tags:          fm.Tags,
+				Authors:       fm.Authors,
+				Image:         firstImage(document, filepath.Dir(name), blog.Href(name)),
+				Series:        series,
+				SeriesOrder:   seriesOrder,
+				Hierarchy:     hierarchy,
+				Draft:         fm.Draft,
+				Unlisted:      fm.Unlisted,
+				PublishAt:     publishAt,
+				WordCount:     stats.Words,
+				CodeLines:     stats.CodeLines,
+				ReadingTime:   int64(stats.ReadingTime() / time.Minute),
+				terms:         terms(document, b),
+			})
 		}
-		index[page] = append(index[page], Index{
-			EntryName:     entry.Name(),
-			Title:         fmt.Sprintf("%v", metaData["title"]),
-			Description:   fmt.Sprintf("%v", metaData["description"]),
-			PublishedDate: date.Unix(),
-			Href:          path.Join("/blog", entry.Name()),
-			Tags:          tags,
-			Hierarchy:     hierarchy,
-		})
-		i++
 	}
 
+	computeRelated(index, time.Now())
+
 	return index, nil
 }
 
+// languages returns the languages of the pages, the default language first.
+func languages(pages []Index) []string {
+	langs := []string{i18n.Default}
+	for _, p := range pages {
+		if !slices.Contains(langs, p.Lang) {
+			langs = append(langs, p.Lang)
+		}
+	}
+	sort.Strings(langs[1:])
+	return langs
+}
+
+// MarshalEntries returns the entries of the index in the JSON of the Index type
+// of the generated package, for its SetEntries. The dev server loads them on
+// each build, instead of the compiled entries.
+func MarshalEntries() ([]byte, error) {
+	pages, err := buildPages()
+	if err != nil {
+		return nil, err
+	}
+	type entry struct {
+		Index
+		PublishedDate time.Time
+		UpdatedDate   time.Time
+		PublishAt     time.Time
+		ReadingTime   time.Duration
+		Loc           string
+		Priority      float32
+	}
+	entries := make([]entry, 0, len(pages))
+	for _, p := range pages {
+		e := entry{
+			Index:         p,
+			PublishedDate: time.Unix(p.PublishedDate, 0),
+			UpdatedDate:   time.Unix(p.UpdatedDate, 0),
+			ReadingTime:   time.Duration(p.ReadingTime) * time.Minute,
+			Loc:           href + p.Href,
+			Priority:      0.5,
+		}
+		if p.PublishAt != 0 {
+			e.PublishAt = time.Unix(p.PublishAt, 0)
+		}
+		entries = append(entries, e)
+	}
+	return json.Marshal(entries)
+}
+
 func Generate() {
 	pages, err := buildPages()
 	if err != nil {
@@ -165,25 +306,25 @@ func Generate() {
 				ParseFS(indexTmpl, "templates/index.tmpl"),
 		)
 		if err := t.ExecuteTemplate(&buf, "index", struct {
-			Pages       [][]Index
-			PageSize    int
-			Title       string
-			Href        string
-			AuthorName  string
-			AuthorEmail string
-			Created     int64
-			Updated     int64
-			Description string
+			Pages          []Index
+			Languages      []string
+			ElementPerPage int
+			Title          string
+			Href           string
+			AuthorName     string
+			AuthorEmail    string
+			Created        int64
+			Description    string
 		}{
-			Pages:       pages,
-			PageSize:    len(pages),
-			Updated:     time.Now().Unix(),
-			Title:       title,
-			Href:        href,
-			AuthorName:  authorName,
-			AuthorEmail: authorEmail,
-			Created:     created.Unix(),
-			Description: description,
+			Pages:          pages,
+			Languages:      languages(pages),
+			ElementPerPage: elementPerPage,
+			Title:          title,
+			Href:           href,
+			AuthorName:     authorName,
+			AuthorEmail:    authorEmail,
+			Created:        created.Unix(),
+			Description:    description,
 		}); err != nil {
 			log.Fatal().Err(err).Msg("template failure")
 		}
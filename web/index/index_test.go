@@ -0,0 +1,54 @@
+//go:build build
+
+package index
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	genindex "github.com/Darkness4/blog/web/gen/index"
+)
+
+func TestMarshalEntries(t *testing.T) {
+	t.Chdir(t.TempDir())
+	for name, content := range map[string]string{
+		"pages/blog/2024-01-01-a/page.md": "---\ntitle: A\ntags: [go]\n---\n\n# A\n\nSome text.\n",
+		"pages/blog/2024-02-01-b/page.md": "---\ntitle: B\npublishAt: 2100-01-01T00:00:00Z\n---\n\nText.\n",
+	} {
+		if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(name, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	b, err := MarshalEntries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var entries []genindex.Index
+	if err := json.Unmarshal(b, &entries); err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	b1, a := entries[0], entries[1]
+	if a.Href != "/blog/2024-01-01-a" || a.Title != "A" || !slices.Equal(a.Tags, []string{"go"}) {
+		t.Errorf("unexpected entry: %+v", a)
+	}
+	if a.Loc != href+a.Href || a.ReadingTime != time.Minute {
+		t.Errorf("expected the location and the reading time, got %q, %v", a.Loc, a.ReadingTime)
+	}
+	if !a.PublishedDate.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)) || !a.PublishAt.IsZero() {
+		t.Errorf("expected the published date only, got %v, %v", a.PublishedDate, a.PublishAt)
+	}
+	if b1.Href != "/blog/2024-02-01-b" || b1.Published(time.Now()) {
+		t.Errorf("expected the scheduled entry first, got %+v", b1)
+	}
+}
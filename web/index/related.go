@@ -0,0 +1,150 @@
+//go:build build
+
+package index
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/yuin/goldmark/ast"
+)
+
+// maxRelated is the number of related articles stored per page. The server
+// only shows the ones that are still listed.
+const maxRelated = 5
+
+// tagWeight is the weight of the tag overlap against the text similarity in the
+// relatedness score.
+const tagWeight = 0.5
+
+// terms counts the words of the text of a document. The code blocks are
+// ignored.
+func terms(doc ast.Node, source []byte) map[string]int {
+	counts := make(map[string]int)
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch n := n.(type) {
+		case *ast.FencedCodeBlock, *ast.CodeBlock, *ast.CodeSpan, *ast.HTMLBlock, *ast.RawHTML:
+			return ast.WalkSkipChildren, nil
+		case *ast.Text:
+			words := strings.FieldsFunc(string(n.Value(source)), func(r rune) bool {
+				return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+			})
+			for _, w := range words {
+				if len(w) > 2 {
+					counts[strings.ToLower(w)]++
+				}
+			}
+		}
+		return ast.WalkContinue, nil
+	})
+	return counts
+}
+
+// computeRelated sets the related articles of the pages, from the overlap of
+// their tags and the TF-IDF similarity of their text. Only the pages in the
+// same language, and listed at now, are related.
+func computeRelated(pages []Index, now time.Time) {
+	// Document frequency of the terms.
+	df := make(map[string]int)
+	for _, p := range pages {
+		for t := range p.terms {
+			df[t]++
+		}
+	}
+	vectors := make([]map[string]float64, len(pages))
+	for i, p := range pages {
+		vectors[i] = tfidf(p.terms, df, len(pages))
+	}
+
+	type candidate struct {
+		href  string
+		score float64
+	}
+	for i := range pages {
+		var candidates []candidate
+		for j := range pages {
+			if i == j || pages[i].Lang != pages[j].Lang || !listedAt(pages[j], now) {
+				continue
+			}
+			score := tagWeight*jaccard(pages[i].Tags, pages[j].Tags) +
+				(1-tagWeight)*cosine(vectors[i], vectors[j])
+			if score > 0 {
+				candidates = append(candidates, candidate{href: pages[j].Href, score: score})
+			}
+		}
+		slices.SortStableFunc(candidates, func(a, b candidate) int {
+			switch {
+			case a.score > b.score:
+				return -1
+			case a.score < b.score:
+				return 1
+			}
+			return 0
+		})
+		for _, c := range candidates[:min(len(candidates), maxRelated)] {
+			pages[i].Related = append(pages[i].Related, c.href)
+		}
+	}
+}
+
+// listedAt reports whether a page is listed at now: it is not a draft, an
+// unlisted page or a scheduled page.
+func listedAt(p Index, now time.Time) bool {
+	return !p.Draft && !p.Unlisted && p.PublishAt <= now.Unix()
+}
+
+// tfidf returns the normalized TF-IDF vector of a document.
+func tfidf(terms map[string]int, df map[string]int, n int) map[string]float64 {
+	total := 0
+	for _, c := range terms {
+		total += c
+	}
+	v := make(map[string]float64, len(terms))
+	var norm float64
+	for t, c := range terms {
+		w := float64(c) / float64(total) * math.Log(float64(n)/float64(df[t]))
+		v[t] = w
+		norm += w * w
+	}
+	if norm == 0 {
+		return v
+	}
+	norm = math.Sqrt(norm)
+	for t := range v {
+		v[t] /= norm
+	}
+	return v
+}
+
+// cosine returns the cosine similarity of two normalized vectors.
+func cosine(a, b map[string]float64) float64 {
+	if len(b) < len(a) {
+		a, b = b, a
+	}
+	var dot float64
+	for t, w := range a {
+		dot += w * b[t]
+	}
+	return dot
+}
+
+// jaccard returns the Jaccard index of two sets of tags.
+func jaccard(a, b []string) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	inter := 0
+	for _, t := range a {
+		if slices.Contains(b, t) {
+			inter++
+		}
+	}
+	union := len(a) + len(b) - inter
+	return float64(inter) / float64(union)
+}
@@ -0,0 +1,134 @@
+//go:build build
+
+package index
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestComputeRelated(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	page := func(href string, lang string, tags []string, terms map[string]int) Index {
+		return Index{Href: href, Lang: lang, Tags: tags, terms: terms}
+	}
+	cgo := map[string]int{"cgo": 3, "library": 2, "linker": 1}
+
+	tests := []struct {
+		title    string
+		pages    []Index
+		expected map[string][]string
+	}{
+		{
+			title: "Ordering",
+			pages: []Index{
+				page("/a", "en", []string{"go", "cgo"}, cgo),
+				page("/b", "en", []string{"go", "cgo"}, map[string]int{"cgo": 2, "library": 1, "binding": 3}),
+				page("/c", "en", []string{"go"}, map[string]int{"http": 4, "server": 2}),
+				page("/d", "en", []string{"rust"}, map[string]int{"borrow": 5}),
+			},
+			expected: map[string][]string{
+				"/a": {"/b", "/c"},
+				"/b": {"/a", "/c"},
+				"/c": {"/a", "/b"},
+				"/d": nil,
+			},
+		},
+		{
+			title: "Language isolation",
+			pages: []Index{
+				page("/a", "en", []string{"cgo"}, cgo),
+				page("/fr/a", "fr", []string{"cgo"}, cgo),
+				page("/b", "en", []string{"cgo"}, map[string]int{"binding": 1}),
+			},
+			expected: map[string][]string{
+				"/a":    {"/b"},
+				"/fr/a": nil,
+				"/b":    {"/a"},
+			},
+		},
+		{
+			title: "Unlisted pages",
+			pages: []Index{
+				page("/a", "en", []string{"cgo"}, cgo),
+				{Href: "/draft", Lang: "en", Tags: []string{"cgo"}, terms: cgo, Draft: true},
+				{Href: "/scheduled", Lang: "en", Tags: []string{"cgo"}, terms: cgo, PublishAt: now.AddDate(0, 0, 1).Unix()},
+				{Href: "/published", Lang: "en", Tags: []string{"cgo"}, terms: cgo, PublishAt: now.AddDate(0, 0, -1).Unix()},
+				{Href: "/unlisted", Lang: "en", Tags: []string{"cgo"}, terms: cgo, Unlisted: true},
+			},
+			expected: map[string][]string{
+				"/a":         {"/published"},
+				"/draft":     {"/a", "/published"},
+				"/scheduled": {"/a", "/published"},
+				"/published": {"/a"},
+				"/unlisted":  {"/a", "/published"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			computeRelated(tt.pages, now)
+			for _, p := range tt.pages {
+				if !slices.Equal(p.Related, tt.expected[p.Href]) {
+					t.Errorf("%s: expected %v, got %v", p.Href, tt.expected[p.Href], p.Related)
+				}
+			}
+		})
+	}
+}
+
+func TestComputeRelatedMax(t *testing.T) {
+	// The pages share fewer tags with the first one as i grows.
+	tags := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+	var pages []Index
+	for i := range len(tags) {
+		pages = append(pages, Index{
+			Href:  fmt.Sprintf("/%d", i),
+			Lang:  "en",
+			Tags:  tags[:len(tags)-i],
+			terms: map[string]int{"word": 1},
+		})
+	}
+	computeRelated(pages, time.Now())
+	expected := []string{"/1", "/2", "/3", "/4", "/5"}
+	if !slices.Equal(pages[0].Related, expected) {
+		t.Errorf("expected %v, got %v", expected, pages[0].Related)
+	}
+}
+
+func TestJaccard(t *testing.T) {
+	tests := []struct {
+		a, b     []string
+		expected float64
+	}{
+		{a: nil, b: []string{"go"}, expected: 0},
+		{a: []string{"go"}, b: []string{"go"}, expected: 1},
+		{a: []string{"go", "cgo"}, b: []string{"go", "rust"}, expected: 1.0 / 3},
+		{a: []string{"go"}, b: []string{"rust"}, expected: 0},
+	}
+	for _, tt := range tests {
+		if got := jaccard(tt.a, tt.b); math.Abs(got-tt.expected) > 1e-9 {
+			t.Errorf("jaccard(%v, %v): expected %v, got %v", tt.a, tt.b, tt.expected, got)
+		}
+	}
+}
+
+func TestTFIDF(t *testing.T) {
+	df := map[string]int{"common": 4, "rare": 1, "some": 2}
+	v := tfidf(map[string]int{"common": 5, "rare": 1, "some": 1}, df, 4)
+	if v["common"] != 0 {
+		t.Errorf("expected no weight for a term of every document, got %v", v["common"])
+	}
+	if v["rare"] <= v["some"] {
+		t.Errorf("expected the rarer term to weigh more, got %v", v)
+	}
+	if norm := cosine(v, v); math.Abs(norm-1) > 1e-9 {
+		t.Errorf("expected a normalized vector, got a norm of %v", norm)
+	}
+	if empty := tfidf(map[string]int{"common": 1}, df, 4); empty["common"] != 0 {
+		t.Errorf("expected a null vector, got %v", empty)
+	}
+}
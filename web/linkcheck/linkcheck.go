@@ -0,0 +1,186 @@
+//go:build build
+
+// Package linkcheck checks the internal links of the pages at build time.
+package linkcheck
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/yuin/goldmark/ast"
+)
+
+var (
+	// ErrNotFound is returned when a link targets a missing page or file.
+	ErrNotFound = errors.New("no such page or file")
+	// ErrAnchorNotFound is returned when a link targets a missing anchor.
+	ErrAnchorNotFound = errors.New("no such anchor")
+)
+
+// Error is a broken link.
+type Error struct {
+	Path        string
+	Line        int
+	Destination string
+	Err         error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s:%d: %s: %v", e.Path, e.Line, e.Destination, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Link is a link or an image of a page.
+type Link struct {
+	Line        int
+	Destination string
+	Image       bool
+}
+
+// Links returns the links and the images of a document.
+func Links(doc ast.Node, source []byte) []Link {
+	var links []Link
+	_ = ast.Walk(doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch n := node.(type) {
+		case *ast.Link:
+			links = append(links, Link{
+				Line:        line(n, source),
+				Destination: string(n.Destination),
+			})
+		case *ast.Image:
+			links = append(links, Link{
+				Line:        line(n, source),
+				Destination: string(n.Destination),
+				Image:       true,
+			})
+		}
+		return ast.WalkContinue, nil
+	})
+	return links
+}
+
+// line returns the line of an inline node, or the first line of its block if
+// the inline node has no text.
+func line(n ast.Node, source []byte) int {
+	offset := -1
+	for c := n.FirstChild(); c != nil; c = c.FirstChild() {
+		if t, ok := c.(*ast.Text); ok {
+			offset = t.Segment.Start
+			break
+		}
+	}
+	for p := n; offset < 0 && p != nil; p = p.Parent() {
+		if p.Type() == ast.TypeBlock && p.Lines().Len() > 0 {
+			offset = p.Lines().At(0).Start
+		}
+	}
+	if offset < 0 {
+		return 0
+	}
+	return bytes.Count(source[:offset], []byte("\n")) + 1
+}
+
+// Site is the set of URLs served by the blog.
+type Site struct {
+	// pages maps the pages to their anchors. The anchors of a page are nil if
+	// they are unknown (e.g. a page written in HTML).
+	pages map[string]map[string]struct{}
+	files map[string]struct{}
+}
+
+// NewSite returns an empty site.
+func NewSite() *Site {
+	return &Site{
+		pages: make(map[string]map[string]struct{}),
+		files: make(map[string]struct{}),
+	}
+}
+
+// AddPage adds a page and its anchors. Links to unknown anchors of the page
+// are only reported if anchors is not nil.
+func (s *Site) AddPage(href string, anchors []string) {
+	var set map[string]struct{}
+	if anchors != nil {
+		set = make(map[string]struct{}, len(anchors))
+		for _, a := range anchors {
+			set[a] = struct{}{}
+		}
+	}
+	s.pages[clean(href)] = set
+}
+
+// AddFile adds a file or a route without anchors.
+func (s *Site) AddFile(href string) {
+	s.files[clean(href)] = struct{}{}
+}
+
+// Check returns the broken internal links of the page at path, served at href.
+//
+// Relative links are resolved like a browser would from href. Relative images
+// are resolved from the directory of the page, like images.Replacer does.
+// Links with a scheme or a host are external and ignored.
+func (s *Site) Check(path string, href string, links []Link) error {
+	var errs []error
+	for _, link := range links {
+		if err := s.check(href, link); err != nil {
+			errs = append(errs, &Error{
+				Path:        path,
+				Line:        link.Line,
+				Destination: link.Destination,
+				Err:         err,
+			})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s *Site) check(href string, link Link) error {
+	// Empty and runtime links cannot be resolved at build time.
+	if link.Destination == "" || strings.Contains(link.Destination, "{{") {
+		return nil
+	}
+	u, err := url.Parse(link.Destination)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "" || u.Host != "" {
+		return nil
+	}
+
+	base := &url.URL{Path: href}
+	if link.Image {
+		base.Path = strings.TrimSuffix(href, "/") + "/"
+	}
+	target := base.ResolveReference(u)
+	p := clean(target.Path)
+
+	if anchors, ok := s.pages[p]; ok {
+		if target.Fragment == "" || anchors == nil {
+			return nil
+		}
+		if _, ok := anchors[target.Fragment]; !ok {
+			return ErrAnchorNotFound
+		}
+		return nil
+	}
+	if _, ok := s.files[p]; ok {
+		return nil
+	}
+	return ErrNotFound
+}
+
+func clean(href string) string {
+	if href == "/" {
+		return href
+	}
+	return strings.TrimSuffix(href, "/")
+}
@@ -0,0 +1,107 @@
+//go:build build
+
+package linkcheck_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Darkness4/blog/web/linkcheck"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/text"
+)
+
+func TestLinks(t *testing.T) {
+	source := []byte("# Title\n\nA [link](/blog/a#b) and\nan ![image](a.png).\n\n[empty]()\n")
+	doc := goldmark.New().Parser().Parse(text.NewReader(source))
+
+	links := linkcheck.Links(doc, source)
+	expected := []linkcheck.Link{
+		{Line: 3, Destination: "/blog/a#b"},
+		{Line: 4, Destination: "a.png", Image: true},
+		{Line: 6, Destination: ""},
+	}
+	if len(links) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, links)
+	}
+	for i := range expected {
+		if links[i] != expected[i] {
+			t.Errorf("expected %v, got %v", expected[i], links[i])
+		}
+	}
+}
+
+func TestSiteCheck(t *testing.T) {
+	site := linkcheck.NewSite()
+	site.AddPage("/", nil)
+	site.AddPage("/blog/post", []string{"intro", "usage"})
+	site.AddPage("/fr/blog/post", []string{"intro"})
+	site.AddPage("/about", nil)
+	site.AddFile("/blog/post/page.assets/a.png")
+	site.AddFile("/static/app.css")
+
+	tests := []struct {
+		title       string
+		destination string
+		image       bool
+		expected    error
+	}{
+		{title: "Page", destination: "/blog/post"},
+		{title: "Page with trailing slash", destination: "/blog/post/"},
+		{title: "Anchor", destination: "/blog/post#usage"},
+		{title: "Anchor of the page", destination: "#intro"},
+		{title: "Relative page", destination: "../fr/blog/post#intro"},
+		{title: "Unknown anchors", destination: "/about#anything"},
+		{title: "File", destination: "/static/app.css"},
+		{title: "Relative image", destination: "page.assets/a.png", image: true},
+		{title: "External", destination: "https://example.com/missing"},
+		{title: "Protocol relative", destination: "//example.com/missing"},
+		{title: "Mail", destination: "mailto:someone@example.com"},
+		{title: "Empty", destination: ""},
+		{title: "Runtime action", destination: "{{ $.Path }}/page.assets/missing.png", image: true},
+		{title: "Missing page", destination: "/blog/missing", expected: linkcheck.ErrNotFound},
+		{title: "Missing anchor", destination: "/blog/post#missing", expected: linkcheck.ErrAnchorNotFound},
+		{title: "Missing anchor of the page", destination: "#usage-2", expected: linkcheck.ErrAnchorNotFound},
+		{title: "Missing translation anchor", destination: "/fr/blog/post#usage", expected: linkcheck.ErrAnchorNotFound},
+		{title: "Missing image", destination: "page.assets/b.png", image: true, expected: linkcheck.ErrNotFound},
+		{title: "Image relative to the parent", destination: "a.png", expected: linkcheck.ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			link := linkcheck.Link{Line: 7, Destination: tt.destination, Image: tt.image}
+			err := site.Check("pages/blog/post/page.md", "/blog/post", []linkcheck.Link{link})
+			if tt.expected == nil {
+				if err != nil {
+					t.Errorf("expected no error, got %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, err)
+			}
+			var linkErr *linkcheck.Error
+			if !errors.As(err, &linkErr) {
+				t.Fatalf("expected a *linkcheck.Error, got %T", err)
+			}
+			if linkErr.Path != "pages/blog/post/page.md" || linkErr.Line != 7 || linkErr.Destination != tt.destination {
+				t.Errorf("unexpected report: %v", linkErr)
+			}
+		})
+	}
+}
+
+func TestSiteCheckReport(t *testing.T) {
+	site := linkcheck.NewSite()
+	site.AddPage("/blog/post", []string{"intro"})
+
+	err := site.Check("pages/blog/post/page.md", "/blog/post", []linkcheck.Link{
+		{Line: 3, Destination: "/blog/missing"},
+		{Line: 4, Destination: "#intro"},
+		{Line: 5, Destination: "#missing"},
+	})
+	expected := "pages/blog/post/page.md:3: /blog/missing: no such page or file\n" +
+		"pages/blog/post/page.md:5: #missing: no such anchor"
+	if err == nil || err.Error() != expected {
+		t.Errorf("expected\n%s\ngot\n%v", expected, err)
+	}
+}
@@ -0,0 +1,138 @@
+package web
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"path"
+	"slices"
+	"time"
+)
+
+// memFS is a read-only file system in memory. It is safe for concurrent use.
+type memFS struct {
+	files map[string]*memFile
+	dirs  map[string]*memDir
+}
+
+type memFile struct {
+	name    string
+	data    []byte
+	mode    fs.FileMode
+	modTime time.Time
+}
+
+type memDir struct {
+	*memFile
+	// entries are sorted by name.
+	entries []fs.DirEntry
+}
+
+// copyFS reads the files of fsys in memory.
+func copyFS(fsys fs.FS) (*memFS, error) {
+	m := &memFS{
+		files: make(map[string]*memFile),
+		dirs:  map[string]*memDir{".": {memFile: &memFile{name: ".", mode: fs.ModeDir | 0o555}}},
+	}
+	err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil || name == "." {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			dir := &memDir{memFile: &memFile{name: path.Base(name), mode: fs.ModeDir | 0o555, modTime: info.ModTime()}}
+			m.dirs[name] = dir
+			info = dir.memFile
+		} else {
+			b, err := fs.ReadFile(fsys, name)
+			if err != nil {
+				return err
+			}
+			f := &memFile{name: path.Base(name), data: b, mode: info.Mode().Perm(), modTime: info.ModTime()}
+			m.files[name] = f
+			info = f
+		}
+		// WalkDir visits the entries of a directory in lexical order.
+		dir := m.dirs[path.Dir(name)]
+		dir.entries = append(dir.entries, fs.FileInfoToDirEntry(info))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Open implements fs.FS.
+func (m *memFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	if f, ok := m.files[name]; ok {
+		return &openFile{memFile: f, Reader: bytes.NewReader(f.data)}, nil
+	}
+	if dir, ok := m.dirs[name]; ok {
+		return &openDir{memDir: dir}, nil
+	}
+	return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+}
+
+// ReadDir implements fs.ReadDirFS.
+func (m *memFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrInvalid}
+	}
+	dir, ok := m.dirs[name]
+	if !ok {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+	}
+	return slices.Clone(dir.entries), nil
+}
+
+func (f *memFile) Name() string       { return f.name }
+func (f *memFile) Size() int64        { return int64(len(f.data)) }
+func (f *memFile) Mode() fs.FileMode  { return f.mode }
+func (f *memFile) ModTime() time.Time { return f.modTime }
+func (f *memFile) IsDir() bool        { return f.mode.IsDir() }
+func (f *memFile) Sys() any           { return nil }
+
+// openFile is an open regular file of a memFS.
+type openFile struct {
+	*memFile
+	*bytes.Reader
+}
+
+func (f *openFile) Stat() (fs.FileInfo, error) { return f.memFile, nil }
+func (f *openFile) Close() error               { return nil }
+
+// Size is the size of the file, not the unread length of the Reader.
+func (f *openFile) Size() int64 { return f.memFile.Size() }
+
+// openDir is an open directory of a memFS.
+type openDir struct {
+	*memDir
+	offset int
+}
+
+func (d *openDir) Stat() (fs.FileInfo, error) { return d.memFile, nil }
+func (d *openDir) Close() error               { return nil }
+
+func (d *openDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.name, Err: fs.ErrInvalid}
+}
+
+// ReadDir implements fs.ReadDirFile.
+func (d *openDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	rest := d.entries[d.offset:]
+	if n > 0 && len(rest) == 0 {
+		return nil, io.EOF
+	}
+	if n > 0 && n < len(rest) {
+		rest = rest[:n]
+	}
+	d.offset += len(rest)
+	return slices.Clone(rest), nil
+}
@@ -0,0 +1,50 @@
+// Package preview signs and verifies the tokens granting access to
+// unpublished pages (drafts and scheduled pages).
+package preview
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// QueryParam is the query parameter carrying the token.
+const QueryParam = "preview"
+
+// Sign returns a token granting access to the page at path until expires.
+func Sign(secret []byte, path string, expires time.Time) string {
+	exp := strconv.FormatInt(expires.Unix(), 10)
+	return exp + "." + base64.RawURLEncoding.EncodeToString(mac(secret, path, exp))
+}
+
+// Verify reports whether token grants access to the page at path at t.
+func Verify(secret []byte, token string, path string, t time.Time) bool {
+	if len(secret) == 0 {
+		return false
+	}
+	exp, sig, ok := strings.Cut(token, ".")
+	if !ok {
+		return false
+	}
+	expires, err := strconv.ParseInt(exp, 10, 64)
+	if err != nil || t.Unix() > expires {
+		return false
+	}
+	got, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal(got, mac(secret, path, exp))
+}
+
+func mac(secret []byte, path string, exp string) []byte {
+	path = strings.TrimSuffix(strings.ToLower(path), "/")
+	h := hmac.New(sha256.New, secret)
+	h.Write([]byte(path))
+	h.Write([]byte{0})
+	h.Write([]byte(exp))
+	return h.Sum(nil)
+}
@@ -0,0 +1,38 @@
+package preview_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Darkness4/blog/web/preview"
+)
+
+func TestVerify(t *testing.T) {
+	secret := []byte("secret")
+	now := time.Now()
+	token := preview.Sign(secret, "/blog/2024-01-11-cgo-guide", now.Add(time.Hour))
+
+	tests := []struct {
+		name     string
+		secret   []byte
+		token    string
+		path     string
+		t        time.Time
+		expected bool
+	}{
+		{"valid", secret, token, "/blog/2024-01-11-cgo-guide", now, true},
+		{"case-insensitive path", secret, token, "/Blog/2024-01-11-cgo-guide/", now, true},
+		{"other path", secret, token, "/blog/2024-01-27-webauthn-guide", now, false},
+		{"expired", secret, token, "/blog/2024-01-11-cgo-guide", now.Add(2 * time.Hour), false},
+		{"wrong secret", []byte("other"), token, "/blog/2024-01-11-cgo-guide", now, false},
+		{"no secret", nil, token, "/blog/2024-01-11-cgo-guide", now, false},
+		{"malformed", secret, "garbage", "/blog/2024-01-11-cgo-guide", now, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := preview.Verify(tt.secret, tt.token, tt.path, tt.t); got != tt.expected {
+				t.Fatalf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
@@ -2,35 +2,46 @@
 package web
 
 import (
+	"bytes"
 	"context"
 	"embed"
 	"errors"
 	"fmt"
 	"io"
 	"io/fs"
+	"maps"
+	"mime"
 	"net"
 	"net/http"
+	"net/url"
+	"path"
 	"path/filepath"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"text/template"
 	"time"
 
 	"github.com/Darkness4/blog/db"
+	"github.com/Darkness4/blog/utils/blog"
 	"github.com/Darkness4/blog/utils/color"
+	"github.com/Darkness4/blog/utils/i18n"
 	"github.com/Darkness4/blog/utils/math"
 	"github.com/Darkness4/blog/web/gen/index"
+	"github.com/Darkness4/blog/web/preview"
 	"github.com/Masterminds/sprig/v3"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/rs/zerolog/log"
 )
 
-var (
-	//go:embed gen components base.html base.htmx error.tmpl
-	html embed.FS
-	//go:embed static
-	static embed.FS
-)
+//go:embed gen components base.html base.htmx error.tmpl tags.tmpl tag.tmpl archive.tmpl static
+var html embed.FS
+
+// hashedAsset matches the generated assets with the hash of their content in
+// their name, e.g. diagram.0123456789abcdef.svg. They never change.
+var hashedAsset = regexp.MustCompile(`\.[0-9a-f]{16}\.[a-z]+$`)
 
 func ReadUserIP(r *http.Request) string {
 	IPAddress, _, _ := strings.Cut(r.Header.Get("X-Real-IP"), ",")
@@ -52,170 +63,602 @@ func ReadUserIP(r *http.Request) string {
 var funcsMap = func() template.FuncMap {
 	f := sprig.TxtFuncMap()
 	f["computeColorByWord"] = color.ComputeByWord
+	f["localDate"] = i18n.FormatDate
+	f["tagHref"] = func(lang string, tag string) string {
+		return index.LanguagePrefix(lang) + "/tags/" + blog.Slug(tag)
+	}
 	return f
 }
 
-func RenderFunc(
-	q *db.Queries,
-	pool *pgxpool.Pool,
-	publicURL string,
-) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		ctx := r.Context()
-		cleanPath := filepath.Clean(r.URL.Path)
-
-		// Check if asset
-		fpath := filepath.Join("gen/pages", cleanPath)
-		if f, err := html.Open(fpath); err == nil {
-			isPage := func() bool {
-				defer f.Close()
-				finfo, err := f.Stat()
-				if err != nil {
-					log.Err(err).Msg("failed to fetch fileinfo")
-					http.Error(w, err.Error(), http.StatusInternalServerError)
-					return false
-				}
+// Embedded is the content embedded in the binary: the generated pages, the
+// templates and the static files.
+func Embedded() fs.FS {
+	return html
+}
 
-				if finfo.IsDir() {
-					// It's a page, or a file not found
-					return true
-				}
+// ViewsPath is the path of the view counter. The path of the page follows it.
+const ViewsPath = "/views"
 
-				// Serve the file
-				if _, err = io.Copy(w, f); err != nil {
-					log.Err(err).Msg("failed to serve file")
-					http.Error(w, err.Error(), http.StatusInternalServerError)
-				}
-				return false
-			}()
+// ViewsFunc counts a view of a published page, once per IP, and renders its
+// number of views. It is loaded by the pages, which are rendered once for
+// every visitor.
+//
+// If q is nil, the page views are not counted and nothing is rendered.
+func ViewsFunc(q *db.Queries, pool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if q == nil {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		entry, ok := index.Lookup(strings.TrimPrefix(r.URL.Path, ViewsPath))
+		if !ok || !entry.Published(time.Now()) {
+			http.NotFound(w, r)
+			return
+		}
+		pageID := strings.ToLower(entry.Href)
 
-			if !isPage {
-				return
-			}
-		} else if !errors.Is(err, fs.ErrNotExist) {
-			log.Err(err).Msg("failed to read file")
+		ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+		defer cancel()
+		if err := q.CreateOrIncrementPageViewsOnUniqueIP(ctx, pool, pageID, ReadUserIP(r)); err != nil {
+			log.Err(err).Msg("failed to increment page views")
+		}
+		pv, err := q.FindPageViewsOrZero(ctx, pageID)
+		if err != nil {
+			log.Err(err).Msg("failed to fetch page views")
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
 
-		// It's a page
-		templatePath := filepath.Clean(fmt.Sprintf("gen/pages/%s/page.tmpl", cleanPath))
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+		fmt.Fprintf(w, "· %s", i18n.FormatViews(entry.Lang, int64(pv.Views)))
+	}
+}
 
-		// Check if SSR
-		var base string
-		if r.Header.Get("Hx-Boosted") != "true" {
-			// Initial Rendering
-			base = "base.html"
-		} else {
-			// SSR
-			base = "base.htmx"
-		}
+// Pages renders the pages of a content (see Embedded and Content) once, and
+// serves them from memory. It is safe for concurrent use.
+//
+// The rendered pages are dropped when the content is replaced, and when a
+// scheduled page is published. They are then rendered again on demand.
+//
+// Unpublished pages (drafts and scheduled pages) are only rendered with a
+// preview token signed with previewSecret, on each request.
+type Pages struct {
+	fsys          fs.FS
+	publicURL     string
+	previewSecret []byte
 
-		// Set Vary Header to avoid caching
-		w.Header().Set("Vary", "Hx-Request")
+	mu    sync.Mutex
+	cache *pageCache
+}
 
-		// Set up the template
-		t, err := template.New("base").
-			Funcs(funcsMap()).
-			ParseFS(html, base, templatePath, "components/*")
-		if err != nil {
-			if strings.Contains(err.Error(), "no files") {
-				// Render 404
-				if err := renderError(
-					w,
-					r,
-					html,
-					"Oops! The page you were looking for couldn't be found.",
-					http.StatusNotFound,
-				); err != nil {
-					log.Err(err).Msg("failed to render error")
-					http.Error(w, err.Error(), http.StatusInternalServerError)
-					return
+// NewPages returns the pages of the content fsys, none of them rendered.
+func NewPages(fsys fs.FS, publicURL string, previewSecret []byte) *Pages {
+	return &Pages{
+		fsys:          fsys,
+		publicURL:     publicURL,
+		previewSecret: previewSecret,
+	}
+}
+
+// pageCache holds the pages rendered from a snapshot of the content.
+type pageCache struct {
+	fsys fs.FS
+	// snap is the snapshot of a Content, nil for the other file systems.
+	snap *snapshot
+	// expires is the next publication of a scheduled page, if any.
+	expires time.Time
+
+	mu    sync.RWMutex
+	pages map[pageKey]*renderedPage
+}
+
+// pageKey identifies a rendered page. For the 404 pages, only the language
+// and the variant are set.
+type pageKey struct {
+	path     string
+	page     int
+	boosted  bool
+	notFound bool
+	lang     string
+}
+
+type renderedPage struct {
+	code    int
+	noindex bool
+	body    []byte
+}
+
+// load returns the cache of the current content at now.
+func (p *Pages) load(now time.Time) *pageCache {
+	fsys, snap := p.fsys, (*snapshot)(nil)
+	if c, ok := p.fsys.(*Content); ok {
+		snap = c.current.Load()
+		fsys = snap
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if c := p.cache; c != nil && c.snap == snap && (c.expires.IsZero() || now.Before(c.expires)) {
+		return c
+	}
+	c := &pageCache{
+		fsys:  fsys,
+		snap:  snap,
+		pages: make(map[pageKey]*renderedPage),
+	}
+	c.expires, _ = index.NextPublication(now)
+	p.cache = c
+	return c
+}
+
+// Reset drops the rendered pages, e.g. after the content fsys changed in
+// place.
+func (p *Pages) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.cache = nil
+}
+
+// Prerender renders every published page of the content, in both variants,
+// and returns the number of rendered pages.
+func (p *Pages) Prerender() (int, error) {
+	now := time.Now()
+	c := p.load(now)
+	paths, err := pagePaths(c.fsys, now)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, boosted := range []bool{false, true} {
+		for _, lang := range index.Languages {
+			if _, err := c.notFound(lang, boosted); err != nil {
+				return count, err
+			}
+			count++
+		}
+		for _, pagePath := range paths {
+			view, ok := p.resolve(c.fsys, pagePath, now)
+			if !ok {
+				continue
+			}
+			for page := range view.pages {
+				if !view.paginated && page > 0 {
+					break
+				}
+				if _, err := c.page(view, page, boosted); err != nil {
+					return count, fmt.Errorf("%s: %w", pagePath, err)
 				}
-				w.WriteHeader(http.StatusNotFound)
-				return
+				count++
 			}
+		}
+	}
+	return count, nil
+}
 
-			log.Err(err).Msg("template error")
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
+// pagePaths returns the paths of the published pages of fsys at now, sorted.
+// Some of them may not exist, e.g. the tag pages without a template.
+func pagePaths(fsys fs.FS, now time.Time) ([]string, error) {
+	paths := make(map[string]bool)
+	err := fs.WalkDir(fsys, "gen/pages", func(fpath string, d fs.DirEntry, err error) error {
+		if err != nil || d.Name() != "page.tmpl" {
+			return err
+		}
+		pagePath := path.Clean("/" + strings.TrimPrefix(path.Dir(fpath), "gen/pages"))
+		if entry, ok := index.Lookup(pagePath); !ok || entry.Published(now) {
+			paths[pagePath] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, lang := range index.Languages {
+		prefix := index.LanguagePrefix(lang)
+		entries := index.InLanguage(index.ListedAt(now), lang)
+		paths[path.Clean("/"+prefix)] = true
+		paths[prefix+"/tags"] = true
+		for _, tag := range index.TagsOf(entries) {
+			paths[prefix+"/tags/"+tag.Slug] = true
+		}
+		paths[prefix+"/archive"] = true
+		for _, year := range index.Archive(entries, 0, 0) {
+			paths[fmt.Sprintf("%s/archive/%d", prefix, year.Year)] = true
+			for _, month := range year.Months {
+				paths[fmt.Sprintf("%s/archive/%d/%02d", prefix, year.Year, month.Month)] = true
+			}
 		}
+	}
+	return slices.Sorted(maps.Keys(paths)), nil
+}
 
-		pageS := r.URL.Query().Get("page")
-		page, _ := strconv.Atoi(pageS)
+// ServeHTTP serves the pages and their assets.
+func (p *Pages) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	now := time.Now()
+	c := p.load(now)
+	cleanPath := filepath.Clean(r.URL.Path)
+	boosted := r.Header.Get("Hx-Boosted") == "true"
+	lang := index.LanguageOf(cleanPath)
 
-		rctx, cancel := context.WithTimeout(ctx, 5*time.Second)
-		defer cancel()
-		pv, err := q.FindPageViewsOrZero(rctx, strings.ToLower(cleanPath))
-		if err != nil {
-			log.Err(err).Msg("failed to fetch page views")
+	// Check if asset. The assets of an unpublished page are only served with
+	// its preview token, as the page.
+	if entry, ok := LookupPage(cleanPath); !ok || entry.Published(now) || p.previewed(r, entry, now) {
+		if !serveAsset(w, c.fsys, cleanPath) {
+			return
 		}
+	}
 
-		if err := t.ExecuteTemplate(w, "base", struct {
-			Pager struct {
-				First   int
-				Prev    int
-				Current int
-				Next    int
-				Last    int
-			}
-			Index      []index.Index
-			Path       string
-			PublicURL  string
-			PageViewsF string
-			PageViews  int
-		}{
-			PublicURL: publicURL,
-			Path:      r.URL.Path,
-			Pager: struct {
-				First   int
-				Prev    int
-				Current int
-				Next    int
-				Last    int
-			}{
-				First:   0,
-				Prev:    math.MaxI(0, page-1),
-				Current: page,
-				Next:    math.MinI(index.PageSize-1, page+1),
-				Last:    index.PageSize - 1,
-			},
-			Index:      index.Pages[page],
-			PageViewsF: math.FormatNumber(float64(pv.Views + 1)),
-			PageViews:  int(pv.Views + 1),
-		}); err != nil {
-			log.Err(err).Msg("failed to execute template")
+	// Set Vary Header to avoid caching
+	w.Header().Set("Vary", "Hx-Request")
+
+	// It's a page
+	view, ok := p.resolve(c.fsys, cleanPath, now)
+	if !ok {
+		rp, err := c.notFound(lang, boosted)
+		if err != nil {
+			log.Err(err).Msg("failed to render error")
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
-		if cleanPath != "/" {
-			go func() {
-				rctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-				defer cancel()
-				if err := q.CreateOrIncrementPageViewsOnUniqueIP(rctx, pool, strings.ToLower(cleanPath), ReadUserIP(r)); err != nil {
-					log.Err(err).Msg("failed to increment page views")
-				}
-			}()
+		rp.serve(w)
+		return
+	}
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if !view.paginated {
+		page = 0
+	}
+	page = math.MinI(math.MaxI(0, page), len(view.pages)-1)
+
+	var rp *renderedPage
+	var err error
+	if entry, ok := index.Lookup(cleanPath); ok && !entry.Published(now) {
+		if !p.previewed(r, entry, now) {
+			rp, err = c.notFound(lang, boosted)
+		} else {
+			w.Header().Set("Cache-Control", "private, no-store")
+			rp, err = c.render(view, page, boosted)
 		}
+	} else {
+		rp, err = c.page(view, page, boosted)
+	}
+	if err != nil {
+		log.Err(err).Msg("failed to execute template")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
+	rp.serve(w)
 }
 
-// renderError renders an error page on error.
+// previewed reports whether a request carries a preview token of the
+// unpublished page of entry. The assets of a previewed page are requested
+// without the token, which is then read from the page in the Referer.
+func (p *Pages) previewed(r *http.Request, entry index.Index, now time.Time) bool {
+	token := r.URL.Query().Get(preview.QueryParam)
+	if token == "" {
+		if ref, err := url.Parse(r.Referer()); err == nil {
+			token = ref.Query().Get(preview.QueryParam)
+		}
+	}
+	return preview.Verify(p.previewSecret, token, entry.Href, now)
+}
+
+// LookupPage returns the entry of the page at cleanPath, or of the page
+// owning the asset at cleanPath, if any.
+func LookupPage(cleanPath string) (index.Index, bool) {
+	for p := cleanPath; p != "/" && p != "."; p = path.Dir(p) {
+		if entry, ok := index.Lookup(p); ok {
+			return entry, true
+		}
+	}
+	return index.Index{}, false
+}
+
+func (rp *renderedPage) serve(w http.ResponseWriter) {
+	if rp.noindex {
+		w.Header().Set("X-Robots-Tag", "noindex")
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(rp.code)
+	if _, err := w.Write(rp.body); err != nil {
+		log.Err(err).Msg("failed to serve page")
+	}
+}
+
+// pageFiles are the files generated for a page, which are never served as
+// assets: its template, and its feed article in the content of older builds.
+var pageFiles = []string{"page.tmpl", "page.html"}
+
+// serveAsset serves the file of a page at cleanPath, if any. It returns
+// whether the path may be a page.
+func serveAsset(w http.ResponseWriter, fsys fs.FS, cleanPath string) bool {
+	fpath := filepath.Join("gen/pages", cleanPath)
+	if slices.Contains(pageFiles, path.Base(fpath)) {
+		return true
+	}
+	f, err := fsys.Open(fpath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return true
+	} else if err != nil {
+		log.Err(err).Msg("failed to read file")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return false
+	}
+	defer f.Close()
+	finfo, err := f.Stat()
+	if err != nil {
+		log.Err(err).Msg("failed to fetch fileinfo")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return false
+	}
+
+	if finfo.IsDir() {
+		// It's a page, or a file not found
+		return true
+	}
+
+	// Serve the file
+	if ctype := mime.TypeByExtension(filepath.Ext(fpath)); ctype != "" {
+		w.Header().Set("Content-Type", ctype)
+	}
+	if hashedAsset.MatchString(fpath) {
+		w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	}
+	if _, err = io.Copy(w, f); err != nil {
+		log.Err(err).Msg("failed to serve file")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+	return false
+}
+
+// pageView is a page to render, before its pagination.
+type pageView struct {
+	template  string
+	paginated bool
+	pages     [][]index.Index
+	noindex   bool
+	data      pageData
+}
+
+type pageData struct {
+	Pager struct {
+		First   int
+		Prev    int
+		Current int
+		Next    int
+		Last    int
+	}
+	Index        []index.Index
+	Related      []index.Index
+	Tag          index.Tag
+	Tags         []index.Tag
+	Archive      []index.Year
+	ArchiveTitle string
+	Path         string
+	PublicURL    string
+	Robots       string
+	Lang         string
+	LangPrefix   string
+}
+
+// resolve returns the page at cleanPath, if it exists. The path of the page is
+// canonical, so that the number of rendered pages is bounded.
+func (p *Pages) resolve(fsys fs.FS, cleanPath string, now time.Time) (pageView, bool) {
+	robots := "index, follow"
+	var noindex bool
+	var related []index.Index
+	if entry, ok := index.Lookup(cleanPath); ok {
+		if !entry.Listed(now) {
+			robots = "noindex, nofollow"
+			noindex = true
+		}
+		related = relatedEntries(entry, now)
+	}
+
+	// The home page, the tag pages and the archive pages are shared by the
+	// languages.
+	lang := index.LanguageOf(cleanPath)
+	prefix := index.LanguagePrefix(lang)
+	entries := index.InLanguage(index.ListedAt(now), lang)
+	templatePath := filepath.Clean(fmt.Sprintf("gen/pages/%s/page.tmpl", cleanPath))
+	paginated := templatePath == "gen/pages/page.tmpl"
+	var tag index.Tag
+	var tags []index.Tag
+	var archive []index.Year
+	var archiveTitle string
+	switch localPath := strings.TrimPrefix(cleanPath, prefix); {
+	case localPath == "":
+		templatePath = "gen/pages/page.tmpl"
+		paginated = true
+	case localPath == "/tags":
+		templatePath = "tags.tmpl"
+		tags = index.TagsOf(entries)
+	case strings.HasPrefix(localPath, "/tags/"):
+		var ok bool
+		tag, ok = index.LookupTag(entries, strings.TrimPrefix(localPath, "/tags/"))
+		if !ok {
+			return pageView{}, false
+		}
+		templatePath = "tag.tmpl"
+		paginated = true
+		entries = index.WithTag(entries, tag.Slug)
+	case localPath == "/archive" || strings.HasPrefix(localPath, "/archive/"):
+		year, month, ok := parseArchivePath(strings.TrimPrefix(localPath, "/archive"))
+		if ok {
+			archive = index.Archive(entries, year, month)
+		}
+		if !ok || (year != 0 && len(archive) == 0) {
+			return pageView{}, false
+		}
+		templatePath = "archive.tmpl"
+		cleanPath = prefix + "/archive"
+		switch {
+		case month != 0:
+			cleanPath += fmt.Sprintf("/%d/%02d", year, month)
+			archiveTitle = i18n.FormatDate(lang, "January 2006", time.Date(year, month, 1, 0, 0, 0, 0, time.UTC))
+		case year != 0:
+			cleanPath += fmt.Sprintf("/%d", year)
+			archiveTitle = strconv.Itoa(year)
+		default:
+			archiveTitle = i18n.Lookup(lang).Archive
+		}
+	}
+	if _, err := fs.Stat(fsys, templatePath); err != nil {
+		return pageView{}, false
+	}
+
+	return pageView{
+		template:  templatePath,
+		paginated: paginated,
+		pages:     index.Paginate(entries),
+		noindex:   noindex,
+		data: pageData{
+			PublicURL:    p.publicURL,
+			Path:         cleanPath,
+			Robots:       robots,
+			Lang:         lang,
+			LangPrefix:   prefix,
+			Related:      related,
+			Tag:          tag,
+			Tags:         tags,
+			Archive:      archive,
+			ArchiveTitle: archiveTitle,
+		},
+	}, true
+}
+
+// page returns the rendered page of a view, rendering it if needed.
+func (c *pageCache) page(view pageView, page int, boosted bool) (*renderedPage, error) {
+	key := pageKey{path: view.data.Path, page: page, boosted: boosted}
+	c.mu.RLock()
+	rp, ok := c.pages[key]
+	c.mu.RUnlock()
+	if ok {
+		return rp, nil
+	}
+	rp, err := c.render(view, page, boosted)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	c.pages[key] = rp
+	c.mu.Unlock()
+	return rp, nil
+}
+
+// render renders a page of a view.
+func (c *pageCache) render(view pageView, page int, boosted bool) (*renderedPage, error) {
+	t, err := template.New("base").
+		Funcs(funcsMap()).
+		ParseFS(c.fsys, baseTemplate(boosted), view.template, "components/*")
+	if err != nil {
+		return nil, err
+	}
+
+	data := view.data
+	data.Pager.First = 0
+	data.Pager.Prev = math.MaxI(0, page-1)
+	data.Pager.Current = page
+	data.Pager.Next = math.MinI(len(view.pages)-1, page+1)
+	data.Pager.Last = len(view.pages) - 1
+	data.Index = view.pages[page]
+
+	var buf bytes.Buffer
+	if err := t.ExecuteTemplate(&buf, "base", data); err != nil {
+		return nil, err
+	}
+	return &renderedPage{code: http.StatusOK, noindex: view.noindex, body: buf.Bytes()}, nil
+}
+
+// notFound returns the rendered 404 page in lang, rendering it if needed.
+func (c *pageCache) notFound(lang string, boosted bool) (*renderedPage, error) {
+	key := pageKey{notFound: true, lang: lang, boosted: boosted}
+	c.mu.RLock()
+	rp, ok := c.pages[key]
+	c.mu.RUnlock()
+	if ok {
+		return rp, nil
+	}
+	var buf bytes.Buffer
+	if err := renderError(
+		&buf,
+		c.fsys,
+		lang,
+		boosted,
+		"Oops! The page you were looking for couldn't be found.",
+		http.StatusNotFound,
+	); err != nil {
+		return nil, err
+	}
+	rp = &renderedPage{code: http.StatusNotFound, body: buf.Bytes()}
+	c.mu.Lock()
+	c.pages[key] = rp
+	c.mu.Unlock()
+	return rp, nil
+}
+
+// baseTemplate returns the base template of the initial rendering, or of the
+// HTMX-boosted navigations.
+func baseTemplate(boosted bool) string {
+	if boosted {
+		// SSR
+		return "base.htmx"
+	}
+	// Initial Rendering
+	return "base.html"
+}
+
+// parseArchivePath parses the path of an archive page after "/archive": "",
+// "/{year}" or "/{year}/{month}". The year and the month are 0 if not set.
+func parseArchivePath(p string) (year int, month time.Month, ok bool) {
+	if p == "" {
+		return 0, 0, true
+	}
+	parts := strings.Split(strings.TrimPrefix(p, "/"), "/")
+	if len(parts) > 2 {
+		return 0, 0, false
+	}
+	year, err := strconv.Atoi(parts[0])
+	if err != nil || year <= 0 {
+		return 0, 0, false
+	}
+	if len(parts) == 1 {
+		return year, 0, true
+	}
+	m, err := strconv.Atoi(parts[1])
+	if err != nil || m < 1 || m > 12 {
+		return 0, 0, false
+	}
+	return year, time.Month(m), true
+}
+
+// maxRelated is the number of related articles shown at the end of a page.
+const maxRelated = 3
+
+// relatedEntries returns the listed related entries of a page.
+func relatedEntries(entry index.Index, now time.Time) []index.Index {
+	related := make([]index.Index, 0, maxRelated)
+	for _, href := range entry.Related {
+		if r, ok := index.Lookup(href); ok && r.Listed(now) {
+			related = append(related, r)
+		}
+		if len(related) == maxRelated {
+			break
+		}
+	}
+	return related
+}
+
+// renderError renders an error page.
 func renderError(
-	w http.ResponseWriter, r *http.Request,
-	html embed.FS,
+	w io.Writer,
+	fsys fs.FS,
+	lang string,
+	boosted bool,
 	errorMsg string,
 	code int,
 ) error {
-	var base string
-	if r.Header.Get("Hx-Boosted") != "true" {
-		// Initial Rendering
-		base = "base.html"
-	} else {
-		// SSR
-		base = "base.htmx"
-	}
-	ctx := r.Context()
+	ctx := context.Background()
 	ctx = context.WithValue(ctx, "error_code", code)
 	ctx = context.WithValue(ctx, "error_short", http.StatusText(code))
 	ctx = context.WithValue(
@@ -226,17 +669,24 @@ func renderError(
 
 	t, err := template.New("base").
 		Funcs(funcsMap()).
-		ParseFS(html, base, "error.tmpl", "components/*.html")
+		ParseFS(fsys, baseTemplate(boosted), "error.tmpl", "components/*.html")
 	if err != nil {
-		panic(fmt.Sprintf("failed to parse error.tmpl: %v", err))
+		return fmt.Errorf("failed to parse error.tmpl: %w", err)
 	}
 	return t.ExecuteTemplate(w, "base", struct {
 		Context context.Context
+		Lang    string
 	}{
 		Context: ctx,
+		Lang:    lang,
 	})
 }
 
-func StaticFunc() http.Handler {
-	return http.FileServer(http.FS(static))
+// StaticFunc serves the static files of the content fsys, under /static.
+func StaticFunc(fsys fs.FS) http.Handler {
+	static, err := fs.Sub(fsys, "static")
+	if err != nil {
+		panic(err)
+	}
+	return http.StripPrefix("/static", http.FileServer(http.FS(static)))
 }
@@ -0,0 +1,92 @@
+package web_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+
+	"github.com/Darkness4/blog/web"
+)
+
+func newPagesFS(page string) fstest.MapFS {
+	return fstest.MapFS{
+		"base.html":                {Data: []byte(`{{ define "base" }}<html>{{ template "body" . }}</html>{{ end }}`)},
+		"base.htmx":                {Data: []byte(`{{ define "base" }}{{ template "body" . }}{{ end }}`)},
+		"error.tmpl":               {Data: []byte(`{{ define "body" }}{{ .Context.Value "error_code" }}{{ end }}`)},
+		"components/Nav.html":      {Data: []byte(`{{ define "Nav" }}{{ end }}`)},
+		"gen/pages/test/page.tmpl": {Data: []byte(`{{ define "body" }}` + page + ` {{ .Path }}{{ end }}`)},
+	}
+}
+
+func get(t *testing.T, h http.Handler, path string, boosted bool) (int, string) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if boosted {
+		req.Header.Set("Hx-Boosted", "true")
+	}
+	h.ServeHTTP(rec, req)
+	return rec.Code, rec.Body.String()
+}
+
+func TestPages(t *testing.T) {
+	fsys := newPagesFS("v1")
+	fsys["gen/pages/test/page.html"] = &fstest.MapFile{Data: []byte("<p>article</p>")}
+	fsys["gen/pages/test/page.assets/a.svg"] = &fstest.MapFile{Data: []byte("<svg/>")}
+	pages := web.NewPages(fsys, "https://example.com", nil)
+
+	for _, tt := range []struct {
+		path     string
+		boosted  bool
+		expected string
+		code     int
+	}{
+		{path: "/test", expected: "<html>v1 /test</html>", code: http.StatusOK},
+		{path: "/test/", expected: "<html>v1 /test</html>", code: http.StatusOK},
+		{path: "/test", boosted: true, expected: "v1 /test", code: http.StatusOK},
+		{path: "/missing", expected: "<html>404</html>", code: http.StatusNotFound},
+		{path: "/missing", boosted: true, expected: "404", code: http.StatusNotFound},
+		{path: "/test/page.assets/a.svg", expected: "<svg/>", code: http.StatusOK},
+		// The generated files of the pages are not assets.
+		{path: "/test/page.tmpl", expected: "<html>404</html>", code: http.StatusNotFound},
+		{path: "/test/page.html", expected: "<html>404</html>", code: http.StatusNotFound},
+	} {
+		code, body := get(t, pages, tt.path, tt.boosted)
+		if code != tt.code || body != tt.expected {
+			t.Errorf("%s (boosted: %v): expected %d %q, got %d %q",
+				tt.path, tt.boosted, tt.code, tt.expected, code, body)
+		}
+	}
+
+	// The pages are rendered once.
+	fsys["gen/pages/test/page.tmpl"] = newPagesFS("v2")["gen/pages/test/page.tmpl"]
+	if _, body := get(t, pages, "/test", false); body != "<html>v1 /test</html>" {
+		t.Errorf("expected the rendered page, got %q", body)
+	}
+	pages.Reset()
+	if _, body := get(t, pages, "/test", false); body != "<html>v2 /test</html>" {
+		t.Errorf("expected the page rendered again, got %q", body)
+	}
+}
+
+func TestPagesContent(t *testing.T) {
+	content := web.NewContent(newPagesFS("v1"))
+	pages := web.NewPages(content, "https://example.com", nil)
+	n, err := pages.Prerender()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n == 0 {
+		t.Error("expected rendered pages")
+	}
+	if _, body := get(t, pages, "/test", false); body != "<html>v1 /test</html>" {
+		t.Errorf("expected the rendered page, got %q", body)
+	}
+
+	// The pages are rendered again when the content is replaced.
+	content.Replace(newPagesFS("v2"))
+	if _, body := get(t, pages, "/test", false); body != "<html>v2 /test</html>" {
+		t.Errorf("expected the page of the new content, got %q", body)
+	}
+}